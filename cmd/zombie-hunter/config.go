@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect zombie-hunter configuration",
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "Check the config file, policy and rule settings for mistakes",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigValidate,
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "print-effective",
+		Short: "Print the configuration after flags and environment variables are applied",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigPrintEffective,
+	})
+
+	return configCmd
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	// The config itself was validated when it was loaded; building the
+	// detector also checks the policy file and rule names, and building the
+	// owner resolver the owner mapping file
+	if _, err := newDetector(); err != nil {
+		return err
+	}
+	if _, err := cfg.OwnerResolver(); err != nil {
+		return err
+	}
+
+	source := cfgSource
+	if source == "" {
+		source = "built-in defaults"
+	}
+	fmt.Printf("✅ Configuration is valid (%s)\n", source)
+	return nil
+}
+
+func runConfigPrintEffective(cmd *cobra.Command, args []string) error {
+	// Printed configs end up in CI logs, so secrets are left out
+	out, err := yaml.Marshal(cfg.Redacted())
+	if err != nil {
+		return err
+	}
+
+	if cfgSource != "" {
+		fmt.Printf("# Loaded from %s\n", cfgSource)
+	} else {
+		fmt.Printf("# No config file found, using defaults\n")
+	}
+	fmt.Print(string(out))
+	return nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned"
+	"github.com/rrdesai64/zombie-hunter/pkg/controller"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/policy"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+var (
+	leaderElect             bool
+	leaderElectionNamespace string
+	leaderElectionID        string
+	healthAddr              string
+	resyncInterval          time.Duration
+	workers                 int
+	reportInterval          time.Duration
+)
+
+func newControllerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "controller",
+		Short: "Run continuously, applying ZombiePolicies as CronJobs change",
+		Long: `Controller watches CronJobs and Jobs and re-evaluates them whenever they
+change and every --resync-interval. What happens to a zombie is set by the
+ZombiePolicy in its namespace or a ClusterZombiePolicy: Report, Mark,
+Quarantine or Delete. Without a policy zombies are only logged.
+
+Findings are written to a ZombieReport in each namespace and summed up in the
+ClusterZombieReport, both named zombie-hunter.
+
+Install the CRDs and RBAC from deploy/ first. Run several replicas with
+--leader-elect for high availability.`,
+		Args: cobra.NoArgs,
+		RunE: runController,
+	}
+
+	cmd.Flags().BoolVar(&leaderElect, "leader-elect", true, "Use a Lease so only one replica acts at a time")
+	cmd.Flags().StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "Namespace of the Lease, backups and journal (default: the pod's namespace)")
+	cmd.Flags().StringVar(&leaderElectionID, "leader-election-id", "zombie-hunter-controller", "Name of the Lease")
+	cmd.Flags().StringVar(&healthAddr, "health-addr", ":8081", "Address serving /healthz and /readyz; empty disables it")
+	cmd.Flags().DurationVar(&resyncInterval, "resync-interval", controller.DefaultResyncInterval, "How often every CronJob is re-evaluated")
+	cmd.Flags().IntVar(&workers, "workers", 2, "CronJobs evaluated in parallel")
+	cmd.Flags().DurationVar(&reportInterval, "report-interval", controller.DefaultReportInterval, "How often changed results are written to ZombieReports")
+	return cmd
+}
+
+func runController(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	k8sClient, err := newSingleClusterClient("controller")
+	if err != nil {
+		return err
+	}
+	zh, err := versioned.NewForConfig(k8sClient.Config())
+	if err != nil {
+		return err
+	}
+
+	var rules []detector.Rule
+	if cfg.Policy != "" {
+		p, err := policy.Load(cfg.Policy)
+		if err != nil {
+			return fmt.Errorf("failed to load policy: %w", err)
+		}
+		rules = p.DetectorRules()
+	}
+
+	owners, err := cfg.OwnerResolver()
+	if err != nil {
+		return err
+	}
+
+	namespace := leaderElectionNamespace
+	if namespace == "" {
+		namespace = podNamespace()
+	}
+	backupNamespace, journalNamespace := cfg.Backup.Namespace, cfg.Journal.Namespace
+	if backupNamespace == "" {
+		backupNamespace = namespace
+	}
+	if journalNamespace == "" {
+		journalNamespace = namespace
+	}
+
+	hostname, _ := os.Hostname()
+	clientset := k8sClient.Clientset()
+	ctrl := controller.New(clientset, zh, controller.Options{
+		Cluster:        k8sClient.Cluster(),
+		Filter:         cfg.Filter(),
+		ThresholdDays:  cfg.Thresholds.Days,
+		GraceDays:      cfg.Quarantine.GraceDays,
+		RescueDays:     cfg.Quarantine.RescueDays,
+		Rules:          rules,
+		RuleSettings:   cfg.Rules,
+		ResyncInterval: resyncInterval,
+		Workers:        workers,
+		Backups:        actions.NewConfigMapStore(clientset, backupNamespace),
+		Journal:        actions.NewConfigMapJournal(clientset, journalNamespace),
+		Actor:          "zombie-hunter-controller@" + hostname,
+		ReportInterval: reportInterval,
+		Owners:         owners,
+	})
+
+	// A standby replica is ready: it has nothing to do until it leads
+	var leading atomic.Bool
+	if healthAddr != "" {
+		ready := func() bool { return (leaderElect && !leading.Load()) || ctrl.Ready() }
+		server := &http.Server{Addr: healthAddr, Handler: controller.HealthHandler(ready), ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				klog.ErrorS(err, "Health server failed")
+				stop()
+			}
+		}()
+		defer server.Close()
+	}
+
+	if !leaderElect {
+		return ctrl.Run(ctx)
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: leaderElectionID, Namespace: namespace},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: hostname},
+	}
+
+	var failed atomic.Bool
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				leading.Store(true)
+				if err := ctrl.Run(ctx); err != nil {
+					klog.ErrorS(err, "Controller failed")
+					failed.Store(true)
+					stop()
+				}
+			},
+			OnStoppedLeading: func() {
+				leading.Store(false)
+				if ctx.Err() == nil {
+					klog.InfoS("Lost leadership", "lease", namespace+"/"+leaderElectionID)
+					failed.Store(true)
+				}
+				stop()
+			},
+		},
+	})
+	if failed.Load() {
+		return fmt.Errorf("controller stopped")
+	}
+	return nil
+}
+
+// podNamespace is the namespace the controller runs in, from $POD_NAMESPACE
+// or the service account, falling back to "default" outside a cluster
+func podNamespace() string {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns
+	}
+	if data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
+		if ns := strings.TrimSpace(string(data)); ns != "" {
+			return ns
+		}
+	}
+	return metav1.NamespaceDefault
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	deleteMinConfidence int
+	dryRun              string
+	assumeYes           bool
+	forceGitOps         bool
+	forceCluster        bool
+	backupDir           string
+	backupNamespace     string
+)
+
+// Values of --dry-run, as in kubectl
+const (
+	dryRunNone   = "none"
+	dryRunClient = "client"
+	dryRunServer = "server"
+)
+
+func newDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [<namespace>/<name>...]",
+		Short: "Back up and delete zombie CronJobs",
+		Long: `Delete scans the cluster, backs up the selected zombies (without status and
+server-assigned fields) and deletes them. Restore them with
+"zombie-hunter restore <backup-id>".
+
+Without names every zombie that passes the namespace filters and
+--min-confidence is selected. Acknowledged CronJobs are never deleted, and
+CronJobs deployed by Argo CD, Flux or Helm are skipped with the change to make
+at their source instead, unless --force is set.`,
+		RunE: runDelete,
+	}
+
+	cmd.Flags().IntVar(&deleteMinConfidence, "min-confidence", 0, "Only delete zombies with at least this confidence")
+	addActionFlags(cmd)
+	addForceFlag(cmd)
+	addBackupFlags(cmd)
+	return cmd
+}
+
+func newRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <backup-id>",
+		Short: "Recreate CronJobs from a backup taken by delete",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRestore,
+	}
+
+	addActionFlags(cmd)
+	addBackupFlags(cmd)
+	cmd.Flags().BoolVar(&forceCluster, "force", false, "Restore into the current cluster even if the backup was taken from another one")
+	return cmd
+}
+
+// addActionFlags adds the flags shared by commands that change CronJobs
+func addActionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&dryRun, "dry-run", dryRunNone, `"none", "client" (print only) or "server" (validate on the API server)`)
+	cmd.Flags().Lookup("dry-run").NoOptDefVal = dryRunServer
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Don't ask for confirmation")
+}
+
+// addForceFlag adds --force to commands that refuse GitOps-managed CronJobs
+func addForceFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&forceGitOps, "force", false, "Also act on CronJobs managed by Argo CD, Flux or Helm, which will likely recreate them")
+}
+
+// addBackupFlags adds the flags of commands that take or read backups
+func addBackupFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&backupDir, "backup-dir", "", "Directory for backups (default from config, ~/.zombie-hunter/backups)")
+	cmd.Flags().StringVar(&backupNamespace, "backup-namespace", "", "Keep backups as ConfigMaps in this namespace instead of files")
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := checkDryRun(); err != nil {
+		return err
+	}
+
+	client, err := newSingleClusterClient("delete")
+	if err != nil {
+		return err
+	}
+
+	targets, err := findTargets(ctx, client, deleteMinConfidence, args)
+	if err != nil {
+		return err
+	}
+	targets = skipGitOps(targets, forceGitOps)
+	if len(targets) == 0 {
+		fmt.Println("No zombies selected, nothing to delete.")
+		return nil
+	}
+
+	fmt.Printf("Zombies selected in %s:\n", client.Cluster())
+	cronJobs := make([]*batchv1.CronJob, 0, len(targets))
+	for _, t := range targets {
+		fmt.Printf("  %s (%d%% confidence)\n", t, t.zombie.Confidence)
+		cronJobs = append(cronJobs, t.cronJob)
+	}
+
+	return backupAndDelete(ctx, cmd, client, cronJobs)
+}
+
+// backupAndDelete saves a backup of the CronJobs and then deletes them,
+// asking first unless --yes is set or this is a dry run
+func backupAndDelete(ctx context.Context, cmd *cobra.Command, client *k8s.Client, cronJobs []*batchv1.CronJob) error {
+	items := make([]batchv1.CronJob, 0, len(cronJobs))
+	for _, cj := range cronJobs {
+		items = append(items, *cj)
+	}
+
+	store := backupStore(cmd, client.Clientset())
+	backup := actions.NewBackup(client.Cluster(), items, time.Now())
+
+	if dryRun == dryRunClient {
+		fmt.Printf("\nDry run: would back up to %s and delete %d CronJobs\n", store.Location(backup.ID), len(cronJobs))
+		return nil
+	}
+
+	if dryRun == dryRunNone && !assumeYes {
+		if !confirm(os.Stdin, fmt.Sprintf("\nDelete %d CronJobs?", len(cronJobs))) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if dryRun == dryRunNone {
+		if err := store.Save(ctx, backup); err != nil {
+			return fmt.Errorf("failed to save backup, nothing was deleted: %w", err)
+		}
+		fmt.Printf("💾 Backup %s saved to %s\n", backup.ID, store.Location(backup.ID))
+	}
+
+	runner := newRunner(client)
+	failed := 0
+	for _, cj := range cronJobs {
+		if err := runner.Delete(ctx, cj); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("🗑️  Deleted %s/%s%s\n", cj.Namespace, cj.Name, dryRunSuffix())
+	}
+
+	if dryRun == dryRunNone {
+		fmt.Printf("\nUndo with: zombie-hunter restore %s\n", backup.ID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deletes failed", failed, len(cronJobs))
+	}
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := checkDryRun(); err != nil {
+		return err
+	}
+
+	client, err := newSingleClusterClient("restore")
+	if err != nil {
+		return err
+	}
+
+	store := backupStore(cmd, client.Clientset())
+	backup, err := store.Load(ctx, actions.BackupID(args[0]))
+	if err != nil {
+		return fmt.Errorf("failed to load backup: %w", err)
+	}
+	if err := backup.CheckCluster(client.Cluster()); err != nil && !forceCluster {
+		return fmt.Errorf("%w; pass --force to restore it to %s anyway", err, client.Cluster())
+	}
+
+	fmt.Printf("Backup %s holds %d CronJobs:\n", backup.ID, len(backup.CronJobs))
+	for _, cj := range backup.CronJobs {
+		fmt.Printf("  %s/%s\n", cj.Namespace, cj.Name)
+	}
+
+	if dryRun == dryRunClient {
+		fmt.Printf("\nDry run: would restore %d CronJobs to %s\n", len(backup.CronJobs), client.Cluster())
+		return nil
+	}
+
+	if dryRun == dryRunNone && !assumeYes {
+		if !confirm(os.Stdin, fmt.Sprintf("\nRestore %d CronJobs to %s?", len(backup.CronJobs), client.Cluster())) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	runner := newRunner(client)
+	failed := 0
+	for i := range backup.CronJobs {
+		cj := &backup.CronJobs[i]
+		if _, err := runner.Restore(ctx, cj); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("♻️  Restored %s/%s%s\n", cj.Namespace, cj.Name, dryRunSuffix())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d restores failed", failed, len(backup.CronJobs))
+	}
+	return nil
+}
+
+// backupStore returns where backups go: flags first, then the config
+func backupStore(cmd *cobra.Command, clientset kubernetes.Interface) actions.Store {
+	namespace, dir := cfg.Backup.Namespace, cfg.Backup.Dir
+	if cmd.Flags().Changed("backup-namespace") {
+		namespace = backupNamespace
+	}
+	if cmd.Flags().Changed("backup-dir") {
+		dir, namespace = backupDir, ""
+	}
+
+	if namespace != "" {
+		return actions.NewConfigMapStore(clientset, namespace)
+	}
+	return actions.NewDirStore(dir)
+}
+
+func checkDryRun() error {
+	switch dryRun {
+	case dryRunNone, dryRunClient, dryRunServer:
+		return nil
+	}
+	return fmt.Errorf("--dry-run must be %q, %q or %q, got %q", dryRunNone, dryRunClient, dryRunServer, dryRun)
+}
+
+func dryRunSuffix() string {
+	if dryRun == dryRunServer {
+		return " (server dry run)"
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/report"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain <namespace>/<name>",
+		Short: "Show why a CronJob is (or isn't) considered a zombie",
+		Long: `Explain analyzes a single CronJob and prints every signal that went into
+its verdict, with the weight each one contributed to the confidence score.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExplain,
+	}
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	ns, name, ok := strings.Cut(args[0], "/")
+	if !ok || ns == "" || name == "" {
+		return fmt.Errorf("expected <namespace>/<name>, got %q", args[0])
+	}
+
+	d, err := newDetector()
+	if err != nil {
+		return err
+	}
+
+	client, err := newSingleClusterClient("explain")
+	if err != nil {
+		return err
+	}
+
+	cronJob, err := client.GetRawCronJob(ctx, ns, name)
+	if err != nil {
+		return fmt.Errorf("failed to get CronJob %s/%s: %w", ns, name, err)
+	}
+
+	jobs, err := client.JobsForCronJob(ctx, cronJob)
+	if err != nil {
+		return fmt.Errorf("failed to get jobs for %s/%s: %w", ns, name, err)
+	}
+
+	owners, err := cfg.OwnerResolver()
+	if err != nil {
+		return err
+	}
+	var namespaceLabels map[string]string
+	if owners.NeedsNamespaceLabels() {
+		// Only used to name an owner, so explain the CronJob regardless
+		if namespace, err := client.Clientset().CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{}); err == nil {
+			namespaceLabels = namespace.Labels
+		}
+	}
+
+	zombie := d.Analyze(cronJob, jobs)
+	zombie.Cluster = client.Cluster()
+	zombie.Owner, zombie.OwnerSource = owners.Resolve(cronJob, namespaceLabels)
+	zombie.Provenance = k8s.ProvenanceOf(cronJob, actions.FieldManager)
+	zombie.CostEstimate = cfg.CostEstimator().Estimate(cronJob, jobs, time.Now())
+
+	formatter := report.NewFormatter(cfg.Output.Format)
+	return formatter.Explain(zombie, cfg.Thresholds.Days, cfg.Cost.Prices.Currency)
+}
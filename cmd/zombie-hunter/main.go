@@ -4,17 +4,49 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/config"
 	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
 	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/notify"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+	"github.com/rrdesai64/zombie-hunter/pkg/policy"
 	"github.com/rrdesai64/zombie-hunter/pkg/report"
 	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
 )
 
 var (
-	days      int
-	namespace string
-	format    string
+	configFile        string
+	kubeconfig        string
+	kubeContext       string
+	kubeContexts      []string
+	allContexts       bool
+	concurrency       int
+	days              int
+	namespace         string
+	includeNamespaces []string
+	excludeNamespaces []string
+	namespaceSelector string
+	selector          string
+	format            string
+	outputFile        string
+	disabledRules     []string
+	ruleWeights       map[string]string
+	policyFile        string
+	pageSize          int64
+	fullJobs          bool
+	mark              bool
+	notifyEnabled     bool
+
+	// cfg is the effective configuration: file, then flags, then environment
+	cfg *config.Config
+	// cfgSource is the config file cfg was loaded from, if any
+	cfgSource string
 )
 
 func main() {
@@ -24,12 +56,43 @@ func main() {
 		Long: `Zombie Hunter scans your Kubernetes cluster for CronJobs that haven't 
 run successfully in a specified number of days. These "zombies" cost you money
 and clutter your infrastructure.`,
-		RunE: run,
+		PersistentPreRunE: loadConfig,
+		RunE:              run,
 	}
 
-	rootCmd.Flags().IntVar(&days, "days", 30, "Consider zombie if no success in N days")
-	rootCmd.Flags().StringVar(&namespace, "namespace", "", "Kubernetes namespace (empty = all)")
-	rootCmd.Flags().StringVar(&format, "format", "table", "Output format: table, csv, json")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file (default: ./zombie-hunter.yaml or $XDG_CONFIG_HOME/zombie-hunter/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Kubeconfig file (default: $KUBECONFIG, merged, or ~/.kube/config)")
+	rootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "Kubeconfig context to scan (default: current context)")
+	rootCmd.PersistentFlags().StringSliceVar(&kubeContexts, "contexts", nil, "Scan several kubeconfig contexts, e.g. prod,staging")
+	rootCmd.PersistentFlags().BoolVar(&allContexts, "all-contexts", false, "Scan every context in the kubeconfig")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", fleet.DefaultConcurrency, "Clusters to scan in parallel")
+	rootCmd.PersistentFlags().IntVar(&days, "days", 30, "Consider zombie if no success in N days")
+	rootCmd.PersistentFlags().StringVar(&format, "format", "table", "Output format: table, csv, json, prometheus, openmetrics")
+	rootCmd.PersistentFlags().StringSliceVar(&disabledRules, "disable-rule", nil, "Disable a detection rule (repeatable)")
+	rootCmd.PersistentFlags().StringToStringVar(&ruleWeights, "rule-weight", nil, "Scale a rule's signals, e.g. inactivity=1.5 (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&policyFile, "policy", "", "YAML file with custom CEL zombie rules")
+	rootCmd.PersistentFlags().StringVar(&namespace, "namespace", "", "Kubernetes namespace (empty = all)")
+	rootCmd.PersistentFlags().StringSliceVar(&includeNamespaces, "include-namespace", nil, "Only scan these namespaces; globs like team-* allowed (repeatable)")
+	rootCmd.PersistentFlags().StringSliceVar(&excludeNamespaces, "exclude-namespace", nil, "Skip these namespaces; globs like kube-* allowed (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&namespaceSelector, "namespace-selector", "", "Only scan namespaces whose labels match, e.g. team=payments")
+	rootCmd.PersistentFlags().StringVarP(&selector, "selector", "l", "", "Only scan CronJobs whose labels match this selector")
+	rootCmd.PersistentFlags().Int64Var(&pageSize, "page-size", k8s.DefaultPageSize, "Objects per List request; lower it on very large clusters")
+	rootCmd.PersistentFlags().BoolVar(&fullJobs, "full-jobs", false, "Keep complete Job objects instead of only the fields detection needs (for policies that read Job specs)")
+
+	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the report to this file atomically instead of stdout, e.g. for the node_exporter textfile collector")
+	rootCmd.Flags().BoolVar(&notifyEnabled, "notify", true, "Send the zombies to the notification targets in the config file")
+	rootCmd.Flags().BoolVar(&mark, "mark", false, "Label and annotate zombies in the cluster (zombie-hunter.io/status=zombie) and unmark recovered CronJobs")
+
+	rootCmd.AddCommand(newExplainCmd())
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newDeleteCmd())
+	rootCmd.AddCommand(newRestoreCmd())
+	rootCmd.AddCommand(newQuarantineCmd())
+	rootCmd.AddCommand(newReapCmd())
+	rootCmd.AddCommand(newUndoCmd())
+	rootCmd.AddCommand(newJournalCmd())
+	rootCmd.AddCommand(newControllerCmd())
+	rootCmd.AddCommand(newServeCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -37,43 +100,301 @@ and clutter your infrastructure.`,
 	}
 }
 
+// loadConfig builds the effective configuration. Flags override the config
+// file and ZOMBIE_HUNTER_* environment variables override both.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	file := configFile
+	if file == "" {
+		file = os.Getenv(config.EnvPrefix + "CONFIG")
+	}
+	if file == "" {
+		file = config.Discover()
+	}
+
+	c, err := config.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("kubeconfig") {
+		c.Clusters.Kubeconfig = kubeconfig
+	}
+	if flags.Changed("context") {
+		c.Clusters.Contexts = []string{kubeContext}
+		c.Clusters.AllContexts = false
+	}
+	if flags.Changed("contexts") {
+		c.Clusters.Contexts = kubeContexts
+		c.Clusters.AllContexts = false
+	}
+	if flags.Changed("all-contexts") {
+		c.Clusters.AllContexts = allContexts
+		if allContexts {
+			c.Clusters.Contexts = nil
+		}
+	}
+	if flags.Changed("concurrency") {
+		c.Clusters.Concurrency = concurrency
+	}
+	if flags.Changed("days") {
+		c.Thresholds.Days = days
+	}
+	if flags.Changed("format") {
+		c.Output.Format = format
+	}
+	if flags.Changed("output") {
+		c.Output.File = outputFile
+	}
+	if flags.Changed("namespace") {
+		c.Namespaces.Include = nil
+		if namespace != "" {
+			c.Namespaces.Include = []string{namespace}
+		}
+	}
+	if flags.Changed("include-namespace") {
+		c.Namespaces.Include = includeNamespaces
+	}
+	if flags.Changed("exclude-namespace") {
+		c.Namespaces.Exclude = excludeNamespaces
+	}
+	if flags.Changed("namespace-selector") {
+		c.Namespaces.Selector = namespaceSelector
+	}
+	if flags.Changed("selector") {
+		c.Selector = selector
+	}
+	if flags.Changed("page-size") {
+		c.Scan.PageSize = pageSize
+	}
+	if flags.Changed("full-jobs") {
+		c.Scan.FullJobs = fullJobs
+	}
+	if flags.Changed("mark") {
+		c.Scan.Mark = mark
+	}
+	if flags.Changed("policy") {
+		c.Policy = policyFile
+	}
+
+	if c.Rules == nil {
+		c.Rules = map[string]detector.RuleSettings{}
+	}
+	for _, name := range disabledRules {
+		enabled := false
+		s := c.Rules[name]
+		s.Enabled = &enabled
+		c.Rules[name] = s
+	}
+	for name, value := range ruleWeights {
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid --rule-weight %s=%s: %w", name, value, err)
+		}
+		s := c.Rules[name]
+		s.Weight = &weight
+		c.Rules[name] = s
+	}
+
+	if err := c.ApplyEnv(os.LookupEnv); err != nil {
+		return fmt.Errorf("invalid environment: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	cfg = c
+	cfgSource = file
+	return nil
+}
+
 func run(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	// Create K8s client
-	client, err := k8s.NewClient()
+	d, err := newDetector()
+	if err != nil {
+		return err
+	}
+	owners, err := cfg.OwnerResolver()
 	if err != nil {
-		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		return err
 	}
 
-	// Get CronJobs
-	cronJobsList, err := client.GetRawCronJobs(ctx, namespace)
+	contexts, err := cfg.Contexts()
 	if err != nil {
-		return fmt.Errorf("failed to list CronJobs: %w", err)
+		return fmt.Errorf("failed to read kubeconfig contexts: %w", err)
 	}
 
-	// Find zombies
-	var zombies []detector.Zombie
+	// Scan every cluster; one failing cluster doesn't stop the others
+	clusters := fleet.Scan(ctx, contexts, cfg.Clusters.Concurrency,
+		func(ctx context.Context, kubeContext string) (string, []detector.Zombie, k8s.ScanStats, error) {
+			return scanCluster(ctx, d, owners, kubeContext, cfg.Scan.Mark)
+		})
 
-	for _, cronJob := range cronJobsList.Items {
-		// Get Jobs for this CronJob
-		jobsList, err := client.GetRawJobsForCronJob(ctx, cronJob.Namespace, cronJob.Name)
+	failed := fleet.Failed(clusters)
+	for _, c := range failed {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", c.Name, c.Err)
+	}
+	if len(failed) == len(clusters) {
+		return fmt.Errorf("all cluster scans failed")
+	}
 
+	// Format and output
+	formatter := report.NewFormatter(cfg.Output.Format)
+	result := report.Result{
+		Clusters:      clusters,
+		ThresholdDays: cfg.Thresholds.Days,
+		Currency:      cfg.Cost.Prices.Currency,
+	}
+	if cfg.Output.File != "" {
+		err = formatter.OutputFile(result, cfg.Output.File)
+	} else {
+		err = formatter.Output(result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if notifyEnabled && len(cfg.Notifications) > 0 {
+		targets, err := notify.NewTargets(cfg.Notifications)
+		if err != nil {
+			return err
+		}
+		if err := notify.Send(ctx, targets, fleet.Zombies(clusters), cfg.Thresholds.Days, time.Now()); err != nil {
+			return fmt.Errorf("failed to send notifications: %w", err)
+		}
+	}
+	return nil
+}
+
+// scanCluster finds the zombies in one kubeconfig context ("" for the
+// current one) and returns them, with their owners, and the cluster's name.
+// With mark set it also marks zombies and unmarks recovered CronJobs.
+func scanCluster(ctx context.Context, d *detector.Detector, owners *owner.Resolver, kubeContext string, mark bool) (string, []detector.Zombie, k8s.ScanStats, error) {
+	// Create K8s client
+	client, err := k8s.NewClient(cfg.ClientOptions(kubeContext))
+	if err != nil {
+		return kubeContext, nil, k8s.ScanStats{}, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	// List CronJobs and their Jobs
+	inv, err := client.Scan(ctx, cfg.Filter())
+	if err != nil {
+		return client.Cluster(), nil, k8s.ScanStats{}, fmt.Errorf("failed to list CronJobs: %w", err)
+	}
+
+	// Namespace labels only name owners; without them the other rules still
+	// apply, so a missing permission is worth a warning, not a failed scan
+	var namespaceLabels map[string]map[string]string
+	if owners.NeedsNamespaceLabels() {
+		namespaceLabels, err = client.NamespaceLabels(ctx, &inv.Stats)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to get jobs for %s/%s: %v\n",
-				cronJob.Namespace, cronJob.Name, err)
-			continue
+			fmt.Fprintf(os.Stderr, "Warning: %s: failed to list namespaces for owners: %v\n", client.Cluster(), err)
 		}
+	}
+
+	// Find zombies
+	var zombies []detector.Zombie
+	var marks markCounts
+	costs := cfg.CostEstimator()
+	now := time.Now()
+
+	for i := range inv.CronJobs {
+		cronJob := &inv.CronJobs[i]
 
 		// Analyze this CronJob
-		zombie := detector.AnalyzeCronJob(&cronJob, jobsList.Items, days)
+		jobs := inv.Jobs.For(cronJob)
+		zombie := d.Analyze(cronJob, jobs)
+		zombie.Cluster = client.Cluster()
+		zombie.Owner, zombie.OwnerSource = owners.Resolve(cronJob, namespaceLabels[cronJob.Namespace])
+		zombie.Provenance = k8s.ProvenanceOf(cronJob, actions.FieldManager)
+		zombie.CostEstimate = costs.Estimate(cronJob, jobs, now)
 
-		if zombie.IsZombie {
+		if zombie.IsZombie || zombie.Acknowledged {
 			zombies = append(zombies, zombie)
 		}
+		if mark {
+			marks.update(ctx, newRunner(client), cronJob, zombie, now)
+		}
 	}
 
-	// Format and output
-	formatter := report.NewFormatter(format)
-	return formatter.Output(zombies, days)
+	if mark {
+		fmt.Fprintf(os.Stderr, "%s: marked %d zombies, unmarked %d recovered CronJobs", client.Cluster(), marks.marked, marks.unmarked)
+		if marks.failed > 0 {
+			fmt.Fprintf(os.Stderr, ", %d failed", marks.failed)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+
+	return client.Cluster(), zombies, inv.Stats, nil
+}
+
+// markCounts tallies the changes --mark made in one cluster
+type markCounts struct {
+	marked, unmarked, failed int
+}
+
+// update marks a zombie, or unmarks a CronJob that is no longer one
+func (m *markCounts) update(ctx context.Context, runner *actions.Runner, cronJob *batchv1.CronJob, zombie detector.Zombie, now time.Time) {
+	var err error
+	switch {
+	case zombie.IsZombie:
+		if !actions.NeedsMark(cronJob, zombie) {
+			return
+		}
+		if _, err = runner.Mark(ctx, cronJob, zombie, now); err == nil {
+			m.marked++
+		}
+	case actions.Marked(cronJob):
+		if _, err = runner.Unmark(ctx, cronJob); err == nil {
+			m.unmarked++
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		m.failed++
+	}
+}
+
+// newSingleClusterClient creates a client for commands that act on one
+// cluster only
+func newSingleClusterClient(command string) (*k8s.Client, error) {
+	contexts, err := cfg.Contexts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig contexts: %w", err)
+	}
+	if len(contexts) > 1 {
+		return nil, fmt.Errorf("%s works on one cluster; pick it with --context", command)
+	}
+
+	client, err := k8s.NewClient(cfg.ClientOptions(contexts[0]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return client, nil
+}
+
+// newDetector builds a detector from the built-in rules, the policy file and
+// the configured rule settings
+func newDetector() (*detector.Detector, error) {
+	rules := detector.DefaultRegistry()
+
+	if cfg.Policy != "" {
+		p, err := policy.Load(cfg.Policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policy: %w", err)
+		}
+		for _, rule := range p.DetectorRules() {
+			if err := rules.Register(rule); err != nil {
+				return nil, fmt.Errorf("failed to load policy: %w", err)
+			}
+		}
+	}
+
+	if err := rules.Configure(cfg.Rules); err != nil {
+		return nil, err
+	}
+
+	return detector.NewDetector(rules, cfg.Thresholds.Days), nil
 }
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+var (
+	quarantineMinConfidence int
+	graceDays               int
+	quarantineReason        string
+	rescueDays              int
+)
+
+func newQuarantineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quarantine [<namespace>/<name>...]",
+		Short: "Suspend zombie CronJobs and schedule them for deletion",
+		Long: `Quarantine suspends the selected zombies and annotates them with who
+quarantined them, when, why and when "zombie-hunter reap" may delete them.
+Unsuspending a CronJob during the grace period rescues it. CronJobs deployed by
+Argo CD, Flux or Helm are skipped unless --force is set, since their source
+would undo the suspension or recreate them.`,
+		RunE: runQuarantine,
+	}
+
+	cmd.Flags().IntVar(&quarantineMinConfidence, "min-confidence", 0, "Only quarantine zombies with at least this confidence")
+	cmd.Flags().IntVar(&graceDays, "grace-days", 0, "Days before reap may delete (default from config, 14)")
+	cmd.Flags().StringVar(&quarantineReason, "reason", "", "Why the CronJobs are quarantined (default: the confidence score)")
+	addActionFlags(cmd)
+	addForceFlag(cmd)
+	return cmd
+}
+
+func newReapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Delete quarantined CronJobs whose grace period expired",
+		Long: `Reap looks at every quarantined CronJob in scope. Those still suspended
+after their deadline are backed up and deleted. Those a human unsuspended are
+marked as rescued and left out of reports for --rescue-days. CronJobs deployed
+by Argo CD, Flux or Helm are skipped unless --force is set.`,
+		Args: cobra.NoArgs,
+		RunE: runReap,
+	}
+
+	cmd.Flags().IntVar(&rescueDays, "rescue-days", 0, "Days a rescued CronJob is left out of reports (default from config, 90)")
+	addActionFlags(cmd)
+	addForceFlag(cmd)
+	addBackupFlags(cmd)
+	return cmd
+}
+
+func runQuarantine(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := checkDryRun(); err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("grace-days") {
+		cfg.Quarantine.GraceDays = graceDays
+	}
+	if cfg.Quarantine.GraceDays < 1 {
+		return fmt.Errorf("--grace-days must be at least 1")
+	}
+
+	client, err := newSingleClusterClient("quarantine")
+	if err != nil {
+		return err
+	}
+
+	targets, err := findTargets(ctx, client, quarantineMinConfidence, args)
+	if err != nil {
+		return err
+	}
+	targets = skipGitOps(targets, forceGitOps)
+
+	now := time.Now()
+	deleteAfter := now.AddDate(0, 0, cfg.Quarantine.GraceDays)
+
+	var selected []target
+	for _, t := range targets {
+		if detector.ParseHints(t.cronJob).Quarantined() {
+			fmt.Fprintf(os.Stderr, "Skipping %s: already quarantined\n", t)
+			continue
+		}
+		selected = append(selected, t)
+	}
+	if len(selected) == 0 {
+		fmt.Println("No zombies selected, nothing to quarantine.")
+		return nil
+	}
+
+	fmt.Printf("Zombies selected in %s:\n", client.Cluster())
+	for _, t := range selected {
+		fmt.Printf("  %s (%d%% confidence)\n", t, t.zombie.Confidence)
+	}
+
+	if dryRun == dryRunClient {
+		fmt.Printf("\nDry run: would suspend %d CronJobs until %s\n", len(selected), deleteAfter.Format(time.DateOnly))
+		return nil
+	}
+	if dryRun == dryRunNone && !assumeYes {
+		question := fmt.Sprintf("\nSuspend %d CronJobs and delete them after %s?", len(selected), deleteAfter.Format(time.DateOnly))
+		if !confirm(os.Stdin, question) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	runner := newRunner(client)
+	actor := actions.CurrentActor()
+	failed := 0
+	for _, t := range selected {
+		reason := quarantineReason
+		if reason == "" {
+			reason = fmt.Sprintf("zombie with %d%% confidence", t.zombie.Confidence)
+		}
+
+		if _, err := runner.Quarantine(ctx, t.cronJob, actor, reason, deleteAfter, now); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("🔒 Quarantined %s until %s%s\n", t, deleteAfter.Format(time.DateOnly), dryRunSuffix())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d quarantines failed", failed, len(selected))
+	}
+	return nil
+}
+
+func runReap(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := checkDryRun(); err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("rescue-days") {
+		cfg.Quarantine.RescueDays = rescueDays
+	}
+	if cfg.Quarantine.RescueDays < 1 {
+		return fmt.Errorf("--rescue-days must be at least 1")
+	}
+
+	client, err := newSingleClusterClient("reap")
+	if err != nil {
+		return err
+	}
+
+	cronJobs, err := client.ListCronJobs(ctx, cfg.Filter())
+	if err != nil {
+		return fmt.Errorf("failed to list CronJobs: %w", err)
+	}
+
+	now := time.Now()
+	var expired []target
+	var rescued []*batchv1.CronJob
+	for i := range cronJobs {
+		cj := &cronJobs[i]
+		name := cj.Namespace + "/" + cj.Name
+
+		switch actions.QuarantineState(cj, now) {
+		case actions.StatePending:
+			deadline := detector.ParseHints(cj).DeleteAfter
+			fmt.Printf("⏳ %s: quarantined until %s\n", name, deadline.Format(time.DateOnly))
+		case actions.StateInvalid:
+			fmt.Fprintf(os.Stderr, "Warning: %s: quarantined without a valid %s, skipping\n", name, detector.AnnotationDeleteAfter)
+		case actions.StateRescued:
+			rescued = append(rescued, cj)
+		case actions.StateExpired:
+			t := target{cronJob: cj}
+			t.zombie.Provenance = k8s.ProvenanceOf(cj, actions.FieldManager)
+			expired = append(expired, t)
+		}
+	}
+	expired = skipGitOps(expired, forceGitOps)
+
+	runner := newRunner(client)
+	rescuedUntil := now.AddDate(0, 0, cfg.Quarantine.RescueDays)
+	failed := 0
+	for _, cj := range rescued {
+		if dryRun == dryRunClient {
+			fmt.Printf("🛟 Would mark %s/%s as rescued until %s\n", cj.Namespace, cj.Name, rescuedUntil.Format(time.DateOnly))
+			continue
+		}
+		if _, err := runner.Rescue(ctx, cj, rescuedUntil); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("🛟 %s/%s was unsuspended during quarantine; rescued until %s%s\n",
+			cj.Namespace, cj.Name, rescuedUntil.Format(time.DateOnly), dryRunSuffix())
+	}
+
+	if len(expired) == 0 {
+		fmt.Println("No quarantined CronJobs are due for deletion.")
+	} else {
+		fmt.Printf("\nQuarantine expired in %s:\n", client.Cluster())
+		cronJobs := make([]*batchv1.CronJob, 0, len(expired))
+		for _, t := range expired {
+			fmt.Printf("  %s\n", t)
+			cronJobs = append(cronJobs, t.cronJob)
+		}
+		if err := backupAndDelete(ctx, cmd, client, cronJobs); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rescues failed", failed, len(rescued))
+	}
+	return nil
+}
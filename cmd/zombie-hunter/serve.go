@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/metrics"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	metricsAddr  string
+	scanInterval time.Duration
+)
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Rescan on an interval and expose the results as Prometheus metrics",
+		Long: `Serve scans like the root command every --interval and serves the results
+on --metrics-addr at /metrics, for alerting on zombies over time. Nothing in
+the cluster is changed: --mark and scan.mark are ignored.`,
+		Args: cobra.NoArgs,
+		RunE: runServe,
+	}
+
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":9090", "Address serving /metrics")
+	cmd.Flags().DurationVar(&scanInterval, "interval", 5*time.Minute, "Time between scans")
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if scanInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	d, err := newDetector()
+	if err != nil {
+		return err
+	}
+	owners, err := cfg.OwnerResolver()
+	if err != nil {
+		return err
+	}
+	contexts, err := cfg.Contexts()
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig contexts: %w", err)
+	}
+
+	exporter := metrics.NewExporter()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(exporter, collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: metricsAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+	}()
+	defer server.Close()
+	fmt.Fprintf(os.Stderr, "Serving metrics on %s/metrics, scanning every %s\n", metricsAddr, scanInterval)
+
+	ticker := time.NewTicker(scanInterval)
+	defer ticker.Stop()
+	for {
+		clusters := fleet.Scan(ctx, contexts, cfg.Clusters.Concurrency,
+			func(ctx context.Context, kubeContext string) (string, []detector.Zombie, k8s.ScanStats, error) {
+				return scanForMetrics(ctx, d, owners, kubeContext)
+			})
+		for _, c := range fleet.Failed(clusters) {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", c.Name, c.Err)
+		}
+		exporter.Update(clusters, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serveErr:
+			return fmt.Errorf("metrics server failed: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanForMetrics scans one cluster like the root command but never marks
+// CronJobs, so a metrics endpoint doesn't patch them on every interval
+func scanForMetrics(ctx context.Context, d *detector.Detector, owners *owner.Resolver, kubeContext string) (string, []detector.Zombie, k8s.ScanStats, error) {
+	return scanCluster(ctx, d, owners, kubeContext, false)
+}
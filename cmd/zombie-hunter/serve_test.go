@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/config"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeAPI serves one zombie CronJob and records every request that isn't
+// a read
+type fakeAPI struct {
+	mu      sync.Mutex
+	cronJob batchv1.CronJob
+	writes  []string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if req.Method != http.MethodGet {
+		f.mu.Lock()
+		f.writes = append(f.writes, req.Method+" "+req.URL.Path)
+		f.mu.Unlock()
+		json.NewEncoder(w).Encode(f.cronJob)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/cronjobs"):
+		json.NewEncoder(w).Encode(batchv1.CronJobList{Items: []batchv1.CronJob{f.cronJob}})
+	case strings.HasSuffix(req.URL.Path, "/jobs"):
+		json.NewEncoder(w).Encode(batchv1.JobList{})
+	case strings.HasSuffix(req.URL.Path, "/namespaces"):
+		json.NewEncoder(w).Encode(corev1.NamespaceList{})
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func TestScanForMetricsNeverMarks(t *testing.T) {
+	api := &fakeAPI{cronJob: batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "CronJob"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "billing", UID: "1",
+			CreationTimestamp: metav1.NewTime(time.Now().AddDate(0, 0, -60)),
+		},
+		Spec: batchv1.CronJobSpec{Schedule: "* * * * *"},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters: [{name: test, cluster: {server: `+server.URL+`}}]
+users: [{name: test, user: {}}]
+contexts: [{name: test, context: {cluster: test, user: test}}]
+current-context: test
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved := cfg
+	defer func() { cfg = saved }()
+	cfg = config.Default()
+	cfg.Clusters.Kubeconfig = kubeconfig
+	cfg.Journal.Path = filepath.Join(dir, "journal.jsonl")
+	cfg.Scan.Mark = true
+
+	ctx := context.Background()
+	d := detector.NewDetector(detector.DefaultRegistry(), cfg.Thresholds.Days)
+	owners := owner.NewResolver(cfg.Owners.Keys, nil)
+
+	_, zombies, _, err := scanForMetrics(ctx, d, owners, "test")
+	if err != nil {
+		t.Fatalf("scanForMetrics() failed: %v", err)
+	}
+	if len(zombies) != 1 {
+		t.Fatalf("scanForMetrics() found %d zombies; want 1", len(zombies))
+	}
+	if len(api.writes) != 0 {
+		t.Errorf("scanForMetrics() with scan.mark set sent %v; want no writes", api.writes)
+	}
+
+	// The root command's scan does mark, so the fake API would have seen it
+	if _, _, _, err := scanCluster(ctx, d, owners, "test", cfg.Scan.Mark); err != nil {
+		t.Fatalf("scanCluster() failed: %v", err)
+	}
+	if len(api.writes) == 0 {
+		t.Errorf("scanCluster() with mark set sent no writes; want the mark patch")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// target is a zombie picked for an action, with the object it was found in
+type target struct {
+	cronJob *batchv1.CronJob
+	zombie  detector.Zombie
+}
+
+func (t target) String() string {
+	return t.cronJob.Namespace + "/" + t.cronJob.Name
+}
+
+// findTargets scans one cluster and returns the zombies with at least
+// minConfidence, limited to names (<namespace>/<name>) when any are given.
+// Acknowledged CronJobs are never targets.
+func findTargets(ctx context.Context, client *k8s.Client, minConfidence int, names []string) ([]target, error) {
+	for _, name := range names {
+		if ns, n, ok := strings.Cut(name, "/"); !ok || ns == "" || n == "" {
+			return nil, fmt.Errorf("expected <namespace>/<name>, got %q", name)
+		}
+	}
+
+	d, err := newDetector()
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := client.Scan(ctx, cfg.Filter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CronJobs: %w", err)
+	}
+
+	var targets []target
+	found := map[string]bool{}
+	for i := range inv.CronJobs {
+		cronJob := &inv.CronJobs[i]
+		t := target{cronJob: cronJob}
+		if len(names) > 0 && !slices.Contains(names, t.String()) {
+			continue
+		}
+		found[t.String()] = true
+
+		t.zombie = d.Analyze(cronJob, inv.Jobs.For(cronJob))
+		t.zombie.Cluster = client.Cluster()
+		t.zombie.Provenance = k8s.ProvenanceOf(cronJob, actions.FieldManager)
+		if t.zombie.IsZombie && t.zombie.Confidence >= minConfidence {
+			targets = append(targets, t)
+		} else if len(names) > 0 {
+			fmt.Fprintf(os.Stderr, "Skipping %s: not a zombie with confidence ≥%d%%\n", t, minConfidence)
+		}
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			fmt.Fprintf(os.Stderr, "Skipping %s: CronJob not found\n", name)
+		}
+	}
+	return targets, nil
+}
+
+// skipGitOps drops the targets Argo CD, Flux or Helm would recreate,
+// printing what to change at their source instead, unless force is set
+func skipGitOps(targets []target, force bool) []target {
+	if force {
+		return targets
+	}
+	var kept []target
+	for _, t := range targets {
+		if src := t.zombie.GitOps; src != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: managed by %s; %s (--force to act anyway)\n", t, src, src.Action)
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept
+}
+
+// confirm asks a yes/no question on stdin; anything but y or yes is no
+func confirm(in io.Reader, question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
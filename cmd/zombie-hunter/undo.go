@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+var undoLast bool
+
+func newUndoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo [--last | <entry-id>]",
+		Short: "Revert a change recorded in the journal",
+		Long: `Undo applies the inverse of a journaled change: deleted CronJobs are
+recreated, restored ones deleted again, and quarantines or rescues reverted.
+It refuses when the CronJob was changed by someone else since.
+
+List the journal with "zombie-hunter journal".`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runUndo,
+	}
+
+	cmd.Flags().BoolVar(&undoLast, "last", false, "Undo the newest change in this cluster that was not undone yet")
+	addActionFlags(cmd)
+	return cmd
+}
+
+func newJournalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "journal",
+		Short: "List the changes zombie-hunter made",
+		Args:  cobra.NoArgs,
+		RunE:  runJournal,
+	}
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := checkDryRun(); err != nil {
+		return err
+	}
+	if undoLast == (len(args) == 1) {
+		return fmt.Errorf("give either --last or an entry ID")
+	}
+
+	client, err := newSingleClusterClient("undo")
+	if err != nil {
+		return err
+	}
+
+	journal := newJournal(client.Clientset())
+	entries, err := journal.Entries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read journal %s: %w", journal.Location(), err)
+	}
+
+	var entry *actions.Entry
+	if undoLast {
+		entry, err = actions.LastUndoable(entries, client.Cluster())
+	} else {
+		entry, err = actions.FindEntry(entries, args[0])
+	}
+	if err != nil {
+		return err
+	}
+	if by := actions.UndoneBy(entries, entry.ID); by != "" {
+		return fmt.Errorf("entry %s was already undone by %s", entry.ID, by)
+	}
+
+	fmt.Printf("Entry %s: %s %s by %s at %s\n", entry.ID, entry.Action, entry.Object(),
+		entry.Actor, entry.Timestamp.Local().Format(time.DateTime))
+
+	if dryRun == dryRunClient {
+		fmt.Printf("\nDry run: would undo %s of %s\n", entry.Action, entry.Object())
+		return nil
+	}
+	if dryRun == dryRunNone && !assumeYes {
+		if !confirm(os.Stdin, fmt.Sprintf("\nUndo %s of %s?", entry.Action, entry.Object())) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if _, err := newRunner(client).Undo(ctx, entry); err != nil {
+		if errors.Is(err, actions.ErrChanged) {
+			return fmt.Errorf("%w; refusing to overwrite someone else's change", err)
+		}
+		return err
+	}
+	fmt.Printf("↩️  Undid %s of %s%s\n", entry.Action, entry.Object(), dryRunSuffix())
+	return nil
+}
+
+func runJournal(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var journal actions.Journal
+	if cfg.Journal.Namespace == "" {
+		journal = newJournal(nil)
+	} else {
+		client, err := newSingleClusterClient("journal")
+		if err != nil {
+			return err
+		}
+		journal = newJournal(client.Clientset())
+	}
+
+	entries, err := journal.Entries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read journal %s: %w", journal.Location(), err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No changes recorded in %s\n", journal.Location())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tCLUSTER\tACTION\tCRONJOB\tACTOR\tUNDONE BY")
+	for _, e := range entries {
+		action := e.Action
+		if e.Undoes != "" {
+			action += " " + e.Undoes
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.ID, e.Timestamp.Local().Format(time.DateTime),
+			e.Cluster, action, e.Object(), e.Actor, actions.UndoneBy(entries, e.ID))
+	}
+	return w.Flush()
+}
+
+// newRunner creates a runner for the client's cluster that journals every
+// change it makes
+func newRunner(client *k8s.Client) *actions.Runner {
+	runner := actions.NewRunner(client.Clientset(), client.Cluster(), dryRun == dryRunServer)
+	return runner.WithJournal(newJournal(client.Clientset()), actions.CurrentActor())
+}
+
+var (
+	fileJournal     *actions.FileJournal
+	fileJournalOnce sync.Once
+)
+
+// newJournal returns the configured journal. Scans of several clusters
+// share one file journal so their appends don't interleave.
+func newJournal(clientset kubernetes.Interface) actions.Journal {
+	if cfg.Journal.Namespace != "" {
+		return actions.NewConfigMapJournal(clientset, cfg.Journal.Namespace)
+	}
+	fileJournalOnce.Do(func() {
+		fileJournal = actions.NewFileJournal(cfg.Journal.Path)
+	})
+	return fileJournal
+}
@@ -0,0 +1,51 @@
+#!/usr/bin/env bash
+# Regenerates the deepcopy functions, clientset, listers and informers of the
+# zombie-hunter.io API types from their +k8s and +genclient markers. Run it
+# from the repository root after changing pkg/apis.
+set -euo pipefail
+
+CODEGEN_VERSION=${CODEGEN_VERSION:-v0.34.2}
+MODULE=github.com/rrdesai64/zombie-hunter
+APIS=pkg/apis/zombiehunter/v1alpha1
+HEADER=hack/boilerplate.go.txt
+
+# gen runs a code-generator command, from $CODEGEN_BIN when the tools are
+# already built
+gen() {
+	local tool=$1
+	shift
+	if [[ -n "${CODEGEN_BIN:-}" ]]; then
+		"${CODEGEN_BIN}/${tool}" "$@"
+	else
+		go run "k8s.io/code-generator/cmd/${tool}@${CODEGEN_VERSION}" "$@"
+	fi
+}
+
+rm -rf pkg/client/clientset pkg/client/listers pkg/client/informers
+
+gen deepcopy-gen \
+	--go-header-file "${HEADER}" \
+	--output-file zz_generated.deepcopy.go \
+	"./${APIS}"
+
+gen client-gen \
+	--go-header-file "${HEADER}" \
+	--clientset-name versioned \
+	--input-base "" \
+	--input "${MODULE}/${APIS}" \
+	--output-pkg "${MODULE}/pkg/client/clientset" \
+	--output-dir pkg/client/clientset
+
+gen lister-gen \
+	--go-header-file "${HEADER}" \
+	--output-pkg "${MODULE}/pkg/client/listers" \
+	--output-dir pkg/client/listers \
+	"./${APIS}"
+
+gen informer-gen \
+	--go-header-file "${HEADER}" \
+	--versioned-clientset-package "${MODULE}/pkg/client/clientset/versioned" \
+	--listers-package "${MODULE}/pkg/client/listers" \
+	--output-pkg "${MODULE}/pkg/client/informers" \
+	--output-dir pkg/client/informers \
+	"./${APIS}"
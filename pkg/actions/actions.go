@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Runner changes CronJobs in one cluster. In dry-run mode every request is
+// sent with dryRun=All, so the API server validates it without persisting.
+// With a journal every change is recorded so it can be undone.
+type Runner struct {
+	clientset kubernetes.Interface
+	cluster   string
+	dryRun    bool
+	journal   Journal
+	actor     string
+}
+
+// NewRunner creates a runner for the named cluster
+func NewRunner(clientset kubernetes.Interface, cluster string, dryRun bool) *Runner {
+	return &Runner{clientset: clientset, cluster: cluster, dryRun: dryRun}
+}
+
+// WithJournal records every change the runner makes in journal, attributed
+// to actor
+func (r *Runner) WithJournal(journal Journal, actor string) *Runner {
+	r.journal, r.actor = journal, actor
+	return r
+}
+
+// DryRun reports whether changes are only validated
+func (r *Runner) DryRun() bool {
+	return r.dryRun
+}
+
+// Delete deletes a CronJob and, through garbage collection, its Jobs. The
+// delete is conditional on the UID and resourceVersion the caller saw, so a
+// CronJob changed since the scan is left alone.
+func (r *Runner) Delete(ctx context.Context, cronJob *batchv1.CronJob) error {
+	propagation := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+		Preconditions: &metav1.Preconditions{
+			UID:             &cronJob.UID,
+			ResourceVersion: &cronJob.ResourceVersion,
+		},
+		DryRun: r.dryRunOption(),
+	}
+
+	err := r.clientset.BatchV1().CronJobs(cronJob.Namespace).Delete(ctx, cronJob.Name, opts)
+	if err != nil {
+		return fmt.Errorf("delete %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	_, err = r.record(ctx, ActionDelete, cronJob, nil, "")
+	return err
+}
+
+// Restore creates a CronJob from a backup
+func (r *Runner) Restore(ctx context.Context, cronJob *batchv1.CronJob) (*batchv1.CronJob, error) {
+	created, err := r.clientset.BatchV1().CronJobs(cronJob.Namespace).Create(ctx, Sanitize(cronJob), metav1.CreateOptions{
+		DryRun: r.dryRunOption(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("restore %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	if _, err := r.record(ctx, ActionRestore, nil, created, ""); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// record appends an entry for a change that was made. Dry runs change
+// nothing and are not recorded. A failure to record is an error, since the
+// change then cannot be undone or audited.
+func (r *Runner) record(ctx context.Context, action string, before, after *batchv1.CronJob, undoes string) (*Entry, error) {
+	if r.journal == nil || r.dryRun {
+		return nil, nil
+	}
+
+	e := NewEntry(r.cluster, r.actor, action, before, after, time.Now())
+	e.Undoes = undoes
+	if err := r.journal.Append(ctx, e); err != nil {
+		return nil, fmt.Errorf("%s %s succeeded but was not journaled in %s: %w", action, e.Object(), r.journal.Location(), err)
+	}
+	return e, nil
+}
+
+func (r *Runner) dryRunOption() []string {
+	if r.dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// Backup is a set of CronJobs saved before zombie-hunter changed them
+type Backup struct {
+	ID        string
+	Cluster   string
+	CreatedAt time.Time
+	CronJobs  []batchv1.CronJob
+}
+
+// NewBackup creates a backup of sanitized copies of the CronJobs
+func NewBackup(cluster string, cronJobs []batchv1.CronJob, now time.Time) *Backup {
+	b := &Backup{
+		ID:        now.UTC().Format("20060102-150405"),
+		Cluster:   cluster,
+		CreatedAt: now,
+	}
+	for i := range cronJobs {
+		b.CronJobs = append(b.CronJobs, *Sanitize(&cronJobs[i]))
+	}
+	return b
+}
+
+// Sanitize returns a copy of a CronJob that can be created again: status and
+// the fields the API server assigns are removed, everything else is kept
+func Sanitize(cronJob *batchv1.CronJob) *batchv1.CronJob {
+	cj := cronJob.DeepCopy()
+	cj.APIVersion = "batch/v1"
+	cj.Kind = "CronJob"
+	cj.UID = ""
+	cj.ResourceVersion = ""
+	cj.Generation = 0
+	cj.CreationTimestamp = metav1.Time{}
+	cj.DeletionTimestamp = nil
+	cj.DeletionGracePeriodSeconds = nil
+	cj.ManagedFields = nil
+	cj.SelfLink = ""
+	cj.Status = batchv1.CronJobStatus{}
+	return cj
+}
+
+// manifest is the on-disk form of a backup: a v1 List that can also be fed
+// to kubectl apply -f
+type manifest struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Items      []batchv1.CronJob `json:"items"`
+}
+
+// Manifest renders the backup as a YAML List
+func (b *Backup) Manifest() ([]byte, error) {
+	data, err := yaml.Marshal(manifest{APIVersion: "v1", Kind: "List", Items: b.CronJobs})
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("# zombie-hunter backup %s of cluster %s, taken %s\n",
+		b.ID, b.Cluster, b.CreatedAt.UTC().Format(time.RFC3339))
+	return append([]byte(header), data...), nil
+}
+
+// manifestHeader matches the comment Manifest starts with
+var manifestHeader = regexp.MustCompile(`^# zombie-hunter backup \S+ of cluster (.*), taken (\S+)\n`)
+
+// parseHeader reads the cluster and time a manifest was taken from its
+// header comment; both are zero when there is none
+func parseHeader(data []byte) (cluster string, createdAt time.Time) {
+	m := manifestHeader.FindSubmatch(data)
+	if m == nil {
+		return "", time.Time{}
+	}
+	createdAt, _ = time.Parse(time.RFC3339, string(m[2]))
+	return string(m[1]), createdAt
+}
+
+// CheckCluster returns an error unless the backup was taken from cluster
+func (b *Backup) CheckCluster(cluster string) error {
+	if b.Cluster == cluster {
+		return nil
+	}
+	if b.Cluster == "" {
+		return fmt.Errorf("backup %s doesn't record which cluster it was taken from", b.ID)
+	}
+	return fmt.Errorf("backup %s was taken from cluster %q, not %q", b.ID, b.Cluster, cluster)
+}
+
+// parseManifest reads the CronJobs back from a backup manifest
+func parseManifest(data []byte) ([]batchv1.CronJob, error) {
+	var m manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	if m.Kind != "List" {
+		return nil, fmt.Errorf("invalid backup manifest: kind %q, want List", m.Kind)
+	}
+	return m.Items, nil
+}
+
+// Store keeps backups until they are restored
+type Store interface {
+	Save(ctx context.Context, b *Backup) error
+	Load(ctx context.Context, id string) (*Backup, error)
+	// Location describes where a backup is kept, for messages
+	Location(id string) string
+}
+
+// DirStore keeps each backup as <id>.yaml in a local directory
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore creates a store in dir, which is created on first save
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// Save writes the backup; an existing backup with the same ID is an error
+func (s *DirStore) Save(ctx context.Context, b *Backup) error {
+	data, err := b.Manifest()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path(b.ID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads a backup by ID
+func (s *DirStore) Load(ctx context.Context, id string) (*Backup, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, err
+	}
+	cronJobs, err := parseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path(id), err)
+	}
+	b := &Backup{ID: id, CronJobs: cronJobs}
+	b.Cluster, b.CreatedAt = parseHeader(data)
+	return b, nil
+}
+
+func (s *DirStore) Location(id string) string {
+	return s.path(id)
+}
+
+func (s *DirStore) path(id string) string {
+	return filepath.Join(s.dir, id+".yaml")
+}
+
+// Labels and annotations on backup ConfigMaps
+const (
+	LabelManagedBy       = "app.kubernetes.io/managed-by"
+	LabelBackup          = "zombie-hunter.io/backup"
+	AnnotationCluster    = "zombie-hunter.io/cluster"
+	AnnotationCreatedAt  = "zombie-hunter.io/created-at"
+	configMapPrefix      = "zombie-hunter-backup-"
+	configMapManifestKey = "cronjobs.yaml"
+)
+
+// ManagedBy is the value of app.kubernetes.io/managed-by on objects
+// zombie-hunter creates
+const ManagedBy = "zombie-hunter"
+
+// ConfigMapStore keeps each backup in a ConfigMap, so it lives in the
+// cluster next to what it backs up. ConfigMaps are limited to 1 MiB.
+type ConfigMapStore struct {
+	clientset kubernetes.Interface
+	namespace string
+}
+
+// NewConfigMapStore creates a store that writes ConfigMaps to namespace
+func NewConfigMapStore(clientset kubernetes.Interface, namespace string) *ConfigMapStore {
+	return &ConfigMapStore{clientset: clientset, namespace: namespace}
+}
+
+// Save creates the backup ConfigMap; an existing backup with the same ID is
+// an error
+func (s *ConfigMapStore) Save(ctx context.Context, b *Backup) error {
+	data, err := b.Manifest()
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapPrefix + b.ID,
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				LabelManagedBy: ManagedBy,
+				LabelBackup:    "true",
+			},
+			Annotations: map[string]string{
+				AnnotationCluster:   b.Cluster,
+				AnnotationCreatedAt: b.CreatedAt.UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string]string{configMapManifestKey: string(data)},
+	}
+
+	_, err = s.clientset.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	return err
+}
+
+// Load reads a backup by ID
+func (s *ConfigMapStore) Load(ctx context.Context, id string) (*Backup, error) {
+	cm, err := s.clientset.CoreV1().ConfigMaps(s.namespace).Get(ctx, configMapPrefix+id, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("backup %s not found in namespace %s", id, s.namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := cm.Data[configMapManifestKey]
+	if !ok {
+		return nil, errors.New(s.Location(id) + ": no " + configMapManifestKey + " key")
+	}
+	cronJobs, err := parseManifest([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Location(id), err)
+	}
+
+	b := &Backup{ID: id, Cluster: cm.Annotations[AnnotationCluster], CronJobs: cronJobs}
+	if t, err := time.Parse(time.RFC3339, cm.Annotations[AnnotationCreatedAt]); err == nil {
+		b.CreatedAt = t
+	}
+	return b, nil
+}
+
+func (s *ConfigMapStore) Location(id string) string {
+	return "configmap/" + configMapPrefix + id + " in namespace " + s.namespace
+}
+
+// BackupID extracts the backup ID from a file name or ConfigMap name, so
+// either can be passed to restore
+func BackupID(s string) string {
+	s = filepath.Base(s)
+	s = strings.TrimSuffix(s, ".yaml")
+	return strings.TrimPrefix(s, configMapPrefix)
+}
@@ -0,0 +1,147 @@
+package actions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSanitize(t *testing.T) {
+	cj := liveCronJob("default", "billing")
+
+	got := Sanitize(cj)
+
+	if got.UID != "" || got.ResourceVersion != "" || got.Generation != 0 {
+		t.Errorf("server-assigned identity kept: uid=%q rv=%q gen=%d", got.UID, got.ResourceVersion, got.Generation)
+	}
+	if !got.CreationTimestamp.IsZero() || got.ManagedFields != nil {
+		t.Errorf("creationTimestamp or managedFields kept")
+	}
+	if got.Status.LastScheduleTime != nil {
+		t.Errorf("status kept")
+	}
+	if got.Spec.Schedule != cj.Spec.Schedule || got.Labels["team"] != "payments" || got.Annotations["note"] != "keep me" {
+		t.Errorf("spec, labels or annotations lost: %+v", got.ObjectMeta)
+	}
+	if got.APIVersion != "batch/v1" || got.Kind != "CronJob" {
+		t.Errorf("TypeMeta = %v; want batch/v1 CronJob", got.TypeMeta)
+	}
+	if cj.UID == "" {
+		t.Errorf("Sanitize() modified its argument")
+	}
+}
+
+func TestStores(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	cronJobs := []batchv1.CronJob{*liveCronJob("default", "billing"), *liveCronJob("team-a", "report")}
+
+	stores := map[string]Store{
+		"dir":       NewDirStore(t.TempDir()),
+		"configmap": NewConfigMapStore(fake.NewSimpleClientset(), "zombie-hunter"),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			backup := NewBackup("prod", cronJobs, now)
+
+			if err := store.Save(ctx, backup); err != nil {
+				t.Fatalf("Save() failed: %v", err)
+			}
+			if err := store.Save(ctx, backup); err == nil {
+				t.Errorf("Save() of the same backup twice succeeded; want error")
+			}
+
+			loaded, err := store.Load(ctx, backup.ID)
+			if err != nil {
+				t.Fatalf("Load() failed: %v", err)
+			}
+			if loaded.ID != "20261017-120000" {
+				t.Errorf("ID = %q; want 20261017-120000", loaded.ID)
+			}
+			if !reflect.DeepEqual(loaded.CronJobs, backup.CronJobs) {
+				t.Errorf("Load() = %+v; want %+v", loaded.CronJobs, backup.CronJobs)
+			}
+			if loaded.Cluster != "prod" || !loaded.CreatedAt.Equal(now) {
+				t.Errorf("Load() = cluster %q, created %v; want prod, %v", loaded.Cluster, loaded.CreatedAt, now)
+			}
+			if err := loaded.CheckCluster("prod"); err != nil {
+				t.Errorf("CheckCluster(prod) = %v; want nil", err)
+			}
+			if err := loaded.CheckCluster("staging"); err == nil {
+				t.Errorf("CheckCluster(staging) of a prod backup succeeded; want error")
+			}
+		})
+	}
+}
+
+func TestDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	cj := liveCronJob("default", "billing")
+	clientset := fake.NewSimpleClientset(cj)
+	runner := NewRunner(clientset, "prod", false)
+
+	backup := NewBackup("prod", []batchv1.CronJob{*cj}, time.Now())
+	if err := runner.Delete(ctx, cj); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := clientset.BatchV1().CronJobs("default").Get(ctx, "billing", metav1.GetOptions{}); err == nil {
+		t.Fatalf("CronJob still exists after Delete()")
+	}
+
+	if _, err := runner.Restore(ctx, &backup.CronJobs[0]); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	restored, err := clientset.BatchV1().CronJobs("default").Get(ctx, "billing", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("CronJob missing after Restore(): %v", err)
+	}
+	if !reflect.DeepEqual(restored.Spec, cj.Spec) || !reflect.DeepEqual(restored.Labels, cj.Labels) {
+		t.Errorf("restored CronJob differs: %+v", restored)
+	}
+
+	if _, err := runner.Restore(ctx, &backup.CronJobs[0]); err == nil {
+		t.Errorf("Restore() over an existing CronJob succeeded; want error")
+	}
+}
+
+func TestBackupID(t *testing.T) {
+	tests := map[string]string{
+		"20261017-120000":                      "20261017-120000",
+		"/backups/20261017-120000.yaml":        "20261017-120000",
+		"zombie-hunter-backup-20261017-120000": "20261017-120000",
+	}
+	for in, want := range tests {
+		if got := BackupID(in); got != want {
+			t.Errorf("BackupID(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
+
+func liveCronJob(namespace, name string) *batchv1.CronJob {
+	suspend := false
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			UID:               types.UID("uid-" + name),
+			ResourceVersion:   "42",
+			Generation:        3,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			Labels:            map[string]string{"team": "payments"},
+			Annotations:       map[string]string{"note": "keep me"},
+			ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 3 * * *",
+			Suspend:  &suspend,
+		},
+		Status: batchv1.CronJobStatus{LastScheduleTime: &metav1.Time{Time: time.Now()}},
+	}
+}
@@ -0,0 +1,266 @@
+package actions
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Actions recorded in the journal
+const (
+	ActionDelete     = "delete"
+	ActionRestore    = "restore"
+	ActionQuarantine = "quarantine"
+	ActionRescue     = "rescue"
+	ActionMark       = "mark"
+	ActionUnmark     = "unmark"
+	ActionUndo       = "undo"
+)
+
+// Entry records one change zombie-hunter made to one CronJob. Before is nil
+// for creates and After is nil for deletes.
+type Entry struct {
+	ID        string           `json:"id"`
+	Cluster   string           `json:"cluster"`
+	Action    string           `json:"action"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Actor     string           `json:"actor"`
+	Timestamp time.Time        `json:"timestamp"`
+	Undoes    string           `json:"undoes,omitempty"` // ID of the entry an undo reverted
+	Before    *batchv1.CronJob `json:"before,omitempty"`
+	After     *batchv1.CronJob `json:"after,omitempty"`
+}
+
+// NewEntry creates a journal entry. IDs sort in the order entries were made.
+func NewEntry(cluster, actor, action string, before, after *batchv1.CronJob, now time.Time) *Entry {
+	e := &Entry{
+		ID:        fmt.Sprintf("%s-%09d", now.UTC().Format("20060102-150405"), now.Nanosecond()),
+		Cluster:   cluster,
+		Action:    action,
+		Actor:     actor,
+		Timestamp: now,
+		Before:    journalCopy(before),
+		After:     journalCopy(after),
+	}
+	if obj := e.object(); obj != nil {
+		e.Namespace, e.Name = obj.Namespace, obj.Name
+	}
+	return e
+}
+
+// Object names the CronJob the entry is about, as namespace/name
+func (e *Entry) Object() string {
+	return e.Namespace + "/" + e.Name
+}
+
+func (e *Entry) object() *batchv1.CronJob {
+	if e.After != nil {
+		return e.After
+	}
+	return e.Before
+}
+
+// journalCopy drops managedFields, which are large and not needed to undo
+func journalCopy(cronJob *batchv1.CronJob) *batchv1.CronJob {
+	if cronJob == nil {
+		return nil
+	}
+	cj := cronJob.DeepCopy()
+	cj.ManagedFields = nil
+	return cj
+}
+
+// Journal is an append-only record of changes
+type Journal interface {
+	Append(ctx context.Context, e *Entry) error
+	// Entries returns every entry, oldest first
+	Entries(ctx context.Context) ([]Entry, error)
+	// Location describes where the journal is kept, for messages
+	Location() string
+}
+
+// FindEntry returns the entry with the given ID
+func FindEntry(entries []Entry, id string) (*Entry, error) {
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("journal entry %s not found", id)
+}
+
+// UndoneBy returns the ID of the entry that undid id, or "" if it was not
+// undone
+func UndoneBy(entries []Entry, id string) string {
+	for _, e := range entries {
+		if e.Action == ActionUndo && e.Undoes == id {
+			return e.ID
+		}
+	}
+	return ""
+}
+
+// LastUndoable returns the newest entry for the cluster that is not an undo
+// and was not undone yet
+func LastUndoable(entries []Entry, cluster string) (*Entry, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := &entries[i]
+		if e.Cluster != cluster || e.Action == ActionUndo || UndoneBy(entries, e.ID) != "" {
+			continue
+		}
+		return e, nil
+	}
+	return nil, fmt.Errorf("no journal entries to undo for cluster %s", cluster)
+}
+
+// FileJournal keeps entries as JSON lines in a local file. It is safe for
+// concurrent use, e.g. by scans of several clusters.
+type FileJournal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileJournal creates a journal at path, which is created on first append
+func NewFileJournal(path string) *FileJournal {
+	return &FileJournal{path: path}
+}
+
+// Append adds an entry to the end of the file
+func (j *FileJournal) Append(ctx context.Context, e *Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Entries reads the journal; a missing file is an empty journal
+func (j *FileJournal) Entries(ctx context.Context) ([]Entry, error) {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", j.path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", j.path, err)
+	}
+	return entries, nil
+}
+
+func (j *FileJournal) Location() string {
+	return j.path
+}
+
+// Labels and keys of journal ConfigMaps
+const (
+	LabelJournal           = "zombie-hunter.io/journal"
+	journalConfigMapPrefix = "zombie-hunter-journal-"
+	journalEntryKey        = "entry.json"
+)
+
+// ConfigMapJournal keeps each entry in its own ConfigMap, so entries are
+// only ever created, never rewritten
+type ConfigMapJournal struct {
+	clientset kubernetes.Interface
+	namespace string
+}
+
+// NewConfigMapJournal creates a journal that writes ConfigMaps to namespace
+func NewConfigMapJournal(clientset kubernetes.Interface, namespace string) *ConfigMapJournal {
+	return &ConfigMapJournal{clientset: clientset, namespace: namespace}
+}
+
+// Append creates the ConfigMap for an entry
+func (j *ConfigMapJournal) Append(ctx context.Context, e *Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      journalConfigMapPrefix + e.ID,
+			Namespace: j.namespace,
+			Labels: map[string]string{
+				LabelManagedBy: ManagedBy,
+				LabelJournal:   "true",
+			},
+			Annotations: map[string]string{
+				AnnotationCluster: e.Cluster,
+			},
+		},
+		Data: map[string]string{journalEntryKey: string(data)},
+	}
+
+	_, err = j.clientset.CoreV1().ConfigMaps(j.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	return err
+}
+
+// Entries reads every journal ConfigMap in the namespace
+func (j *ConfigMapJournal) Entries(ctx context.Context) ([]Entry, error) {
+	list, err := j.clientset.CoreV1().ConfigMaps(j.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: LabelJournal + "=true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(list.Items))
+	for _, cm := range list.Items {
+		var e Entry
+		if err := json.Unmarshal([]byte(cm.Data[journalEntryKey]), &e); err != nil {
+			return nil, fmt.Errorf("configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].ID < entries[b].ID })
+	return entries, nil
+}
+
+func (j *ConfigMapJournal) Location() string {
+	return "configmaps labelled " + LabelJournal + "=true in namespace " + j.namespace
+}
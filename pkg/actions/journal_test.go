@@ -0,0 +1,172 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestJournals(t *testing.T) {
+	ctx := context.Background()
+	journals := map[string]Journal{
+		"file":      NewFileJournal(filepath.Join(t.TempDir(), "audit", "journal.jsonl")),
+		"configmap": NewConfigMapJournal(fake.NewSimpleClientset(), "zombie-hunter"),
+	}
+
+	for name, journal := range journals {
+		t.Run(name, func(t *testing.T) {
+			entries, err := journal.Entries(ctx)
+			if err != nil || len(entries) != 0 {
+				t.Fatalf("Entries() of an empty journal = %v, %v; want none", entries, err)
+			}
+
+			now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+			cj := liveCronJob("default", "billing")
+			first := NewEntry("prod", "alice@laptop", ActionDelete, cj, nil, now)
+			second := NewEntry("prod", "alice@laptop", ActionRestore, nil, cj, now.Add(time.Millisecond))
+			for _, e := range []*Entry{first, second} {
+				if err := journal.Append(ctx, e); err != nil {
+					t.Fatalf("Append() failed: %v", err)
+				}
+			}
+
+			entries, err = journal.Entries(ctx)
+			if err != nil {
+				t.Fatalf("Entries() failed: %v", err)
+			}
+			if len(entries) != 2 || entries[0].ID != first.ID || entries[1].ID != second.ID {
+				t.Fatalf("Entries() = %+v; want %s then %s", entries, first.ID, second.ID)
+			}
+			got := entries[0]
+			if got.Object() != "default/billing" || got.Actor != "alice@laptop" || got.After != nil {
+				t.Errorf("entry = %+v", got)
+			}
+			if got.Before == nil || got.Before.ResourceVersion != "42" || got.Before.ManagedFields != nil {
+				t.Errorf("before-state = %+v; want resourceVersion kept and managedFields dropped", got.Before)
+			}
+		})
+	}
+}
+
+func TestLastUndoable(t *testing.T) {
+	now := time.Now()
+	cj := liveCronJob("default", "billing")
+	deleted := NewEntry("prod", "alice", ActionDelete, cj, nil, now)
+	quarantined := NewEntry("prod", "alice", ActionQuarantine, cj, cj, now.Add(time.Second))
+	otherCluster := NewEntry("staging", "alice", ActionDelete, cj, nil, now.Add(2*time.Second))
+	undo := NewEntry("prod", "alice", ActionUndo, cj, cj, now.Add(3*time.Second))
+	undo.Undoes = quarantined.ID
+
+	entries := []Entry{*deleted, *quarantined, *otherCluster, *undo}
+
+	got, err := LastUndoable(entries, "prod")
+	if err != nil || got.ID != deleted.ID {
+		t.Errorf("LastUndoable(prod) = %v, %v; want %s", got, err, deleted.ID)
+	}
+	if by := UndoneBy(entries, quarantined.ID); by != undo.ID {
+		t.Errorf("UndoneBy(quarantined) = %q; want %q", by, undo.ID)
+	}
+	if _, err := LastUndoable(entries[1:2], "staging"); err == nil {
+		t.Errorf("LastUndoable() without entries for the cluster succeeded; want error")
+	}
+}
+
+func TestUndo(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("Delete is undone by recreating", func(t *testing.T) {
+		cj := liveCronJob("default", "billing")
+		clientset := fake.NewSimpleClientset(cj)
+		journal := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+		runner := NewRunner(clientset, "prod", false).WithJournal(journal, "alice")
+
+		if err := runner.Delete(ctx, cj); err != nil {
+			t.Fatalf("Delete() failed: %v", err)
+		}
+		entries, _ := journal.Entries(ctx)
+		if len(entries) != 1 || entries[0].Action != ActionDelete {
+			t.Fatalf("journal = %+v; want one delete", entries)
+		}
+
+		undo, err := runner.Undo(ctx, &entries[0])
+		if err != nil {
+			t.Fatalf("Undo() failed: %v", err)
+		}
+		if undo.Undoes != entries[0].ID {
+			t.Errorf("undo entry refers to %q; want %q", undo.Undoes, entries[0].ID)
+		}
+		if _, err := clientset.BatchV1().CronJobs("default").Get(ctx, "billing", metav1.GetOptions{}); err != nil {
+			t.Errorf("CronJob not recreated: %v", err)
+		}
+
+		if _, err := runner.Undo(ctx, &entries[0]); !errors.Is(err, ErrChanged) {
+			t.Errorf("second Undo() error = %v; want ErrChanged", err)
+		}
+	})
+
+	t.Run("Quarantine is undone by restoring metadata", func(t *testing.T) {
+		cj := liveCronJob("default", "billing")
+		clientset := fake.NewSimpleClientset(cj)
+		journal := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+		runner := NewRunner(clientset, "prod", false).WithJournal(journal, "alice")
+
+		if _, err := runner.Quarantine(ctx, cj, "alice", "test", now.AddDate(0, 0, 14), now); err != nil {
+			t.Fatalf("Quarantine() failed: %v", err)
+		}
+		entries, _ := journal.Entries(ctx)
+
+		if _, err := runner.Undo(ctx, &entries[0]); err != nil {
+			t.Fatalf("Undo() failed: %v", err)
+		}
+		reverted, err := clientset.BatchV1().CronJobs("default").Get(ctx, "billing", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if *reverted.Spec.Suspend || detector.ParseHints(reverted).Quarantined() {
+			t.Errorf("quarantine not reverted: suspend=%v annotations=%v", *reverted.Spec.Suspend, reverted.Annotations)
+		}
+		if reverted.Annotations["note"] != "keep me" {
+			t.Errorf("original annotations lost: %v", reverted.Annotations)
+		}
+	})
+
+	t.Run("Refuses when changed by someone else", func(t *testing.T) {
+		cj := liveCronJob("default", "billing")
+		clientset := fake.NewSimpleClientset(cj)
+		runner := NewRunner(clientset, "prod", false)
+
+		quarantined, err := runner.Quarantine(ctx, cj, "alice", "test", now.AddDate(0, 0, 14), now)
+		if err != nil {
+			t.Fatalf("Quarantine() failed: %v", err)
+		}
+		entry := NewEntry("prod", "alice", ActionQuarantine, cj, quarantined, now)
+
+		edited := quarantined.DeepCopy()
+		edited.ResourceVersion = "43"
+		edited.Labels["edited"] = "by-bob"
+		if _, err := clientset.BatchV1().CronJobs("default").Update(ctx, edited, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("Update() failed: %v", err)
+		}
+
+		if _, err := runner.Undo(ctx, entry); !errors.Is(err, ErrChanged) {
+			t.Errorf("Undo() error = %v; want ErrChanged", err)
+		}
+	})
+
+	t.Run("Refuses entries of another cluster", func(t *testing.T) {
+		cj := liveCronJob("default", "billing")
+		runner := NewRunner(fake.NewSimpleClientset(), "prod", false)
+		entry := NewEntry("staging", "alice", ActionDelete, cj, nil, now)
+
+		if _, err := runner.Undo(ctx, entry); err == nil {
+			t.Errorf("Undo() of another cluster's entry succeeded; want error")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchv1ac "k8s.io/client-go/applyconfigurations/batch/v1"
+)
+
+// FieldManager is the server-side apply field manager zombie-hunter marks
+// CronJobs with
+const FieldManager = "zombie-hunter"
+
+// Label and annotations --mark puts on zombies, so dashboards and admission
+// policies can select them
+const (
+	LabelStatus                = "zombie-hunter.io/status"
+	StatusZombie               = "zombie"
+	AnnotationConfidence       = "zombie-hunter.io/confidence"
+	AnnotationDaysSinceSuccess = "zombie-hunter.io/days-since-success"
+	AnnotationScannedAt        = "zombie-hunter.io/scanned-at"
+)
+
+// Marked reports whether a CronJob carries the zombie status label
+func Marked(cronJob *batchv1.CronJob) bool {
+	_, ok := cronJob.Labels[LabelStatus]
+	return ok
+}
+
+// NeedsMark reports whether the marks on a zombie are missing or stale.
+// Marks are only rewritten when the status or confidence changes, since
+// every write bumps the CronJob's resourceVersion and so fails the
+// precondition of undoing earlier journal entries. Days since success grows
+// every day, so it is left out; its annotation is as of scanned-at.
+func NeedsMark(cronJob *batchv1.CronJob, zombie detector.Zombie) bool {
+	return cronJob.Labels[LabelStatus] != StatusZombie ||
+		cronJob.Annotations[AnnotationConfidence] != strconv.Itoa(zombie.Confidence)
+}
+
+// Mark labels and annotates a zombie with its scan result; scanned-at is
+// the scan that last changed the marks, and days since success is as of it. Newly marked CronJobs are
+// journaled; refreshing an existing mark is not. Callers skip CronJobs
+// whose marks are current, see NeedsMark.
+func (r *Runner) Mark(ctx context.Context, cronJob *batchv1.CronJob, zombie detector.Zombie, now time.Time) (*batchv1.CronJob, error) {
+	ac := batchv1ac.CronJob(cronJob.Name, cronJob.Namespace).
+		WithLabels(map[string]string{LabelStatus: StatusZombie}).
+		WithAnnotations(map[string]string{
+			AnnotationConfidence:       strconv.Itoa(zombie.Confidence),
+			AnnotationDaysSinceSuccess: strconv.Itoa(zombie.DaysSinceSuccess),
+			AnnotationScannedAt:        now.UTC().Format(time.RFC3339),
+		})
+
+	updated, err := r.apply(ctx, ac)
+	if err != nil {
+		return nil, fmt.Errorf("mark %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	if !Marked(cronJob) {
+		if _, err := r.record(ctx, ActionMark, cronJob, updated, ""); err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+// Unmark removes the label and annotations Mark set from a CronJob that is
+// no longer a zombie. Applying an empty configuration drops every field the
+// zombie-hunter field manager owns and leaves the rest alone.
+func (r *Runner) Unmark(ctx context.Context, cronJob *batchv1.CronJob) (*batchv1.CronJob, error) {
+	updated, err := r.apply(ctx, batchv1ac.CronJob(cronJob.Name, cronJob.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("unmark %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	if _, err := r.record(ctx, ActionUnmark, cronJob, updated, ""); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
+
+func (r *Runner) apply(ctx context.Context, ac *batchv1ac.CronJobApplyConfiguration) (*batchv1.CronJob, error) {
+	return r.clientset.BatchV1().CronJobs(*ac.Namespace).Apply(ctx, ac, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+		DryRun:       r.dryRunOption(),
+	})
+}
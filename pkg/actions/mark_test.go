@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMarkAndUnmark(t *testing.T) {
+	ctx := context.Background()
+	cj := liveCronJob("default", "billing")
+	cj.ManagedFields = nil
+	clientset := fake.NewClientset(cj)
+	journal := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	runner := NewRunner(clientset, "prod", false).WithJournal(journal, "alice")
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	zombie := detector.Zombie{Confidence: 85, DaysSinceSuccess: 40}
+
+	marked, err := runner.Mark(ctx, cj, zombie, now)
+	if err != nil {
+		t.Fatalf("Mark() failed: %v", err)
+	}
+	if !Marked(marked) || marked.Labels[LabelStatus] != StatusZombie {
+		t.Errorf("labels after Mark() = %v; want %s=%s", marked.Labels, LabelStatus, StatusZombie)
+	}
+	want := map[string]string{
+		AnnotationConfidence:       "85",
+		AnnotationDaysSinceSuccess: "40",
+		AnnotationScannedAt:        "2026-03-01T12:00:00Z",
+	}
+	for k, v := range want {
+		if marked.Annotations[k] != v {
+			t.Errorf("annotation %s = %q; want %q", k, marked.Annotations[k], v)
+		}
+	}
+	if marked.Labels["team"] != "payments" || marked.Annotations["note"] != "keep me" {
+		t.Errorf("Mark() dropped existing metadata: %v %v", marked.Labels, marked.Annotations)
+	}
+
+	// Current marks are left alone, so undo preconditions keep holding,
+	// even as the days since success grow
+	if NeedsMark(marked, zombie) {
+		t.Errorf("NeedsMark() of an up-to-date mark = true; want false")
+	}
+	zombie.DaysSinceSuccess++
+	if NeedsMark(marked, zombie) {
+		t.Errorf("NeedsMark() a day later = true; want false")
+	}
+	zombie.Confidence = 95
+	if !NeedsMark(marked, zombie) {
+		t.Errorf("NeedsMark() after the confidence changed = false; want true")
+	}
+
+	// Refreshing a mark is not journaled again
+	marked, err = runner.Mark(ctx, marked, zombie, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("second Mark() failed: %v", err)
+	}
+
+	unmarked, err := runner.Unmark(ctx, marked)
+	if err != nil {
+		t.Fatalf("Unmark() failed: %v", err)
+	}
+	if Marked(unmarked) {
+		t.Errorf("labels after Unmark() = %v; want no %s", unmarked.Labels, LabelStatus)
+	}
+	for k := range want {
+		if _, ok := unmarked.Annotations[k]; ok {
+			t.Errorf("annotation %s kept after Unmark()", k)
+		}
+	}
+	if unmarked.Labels["team"] != "payments" || unmarked.Annotations["note"] != "keep me" {
+		t.Errorf("Unmark() dropped metadata it does not own: %v %v", unmarked.Labels, unmarked.Annotations)
+	}
+
+	entries, err := journal.Entries(ctx)
+	if err != nil {
+		t.Fatalf("Entries() failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != ActionMark || entries[1].Action != ActionUnmark {
+		t.Errorf("journal = %+v; want mark then unmark", entries)
+	}
+}
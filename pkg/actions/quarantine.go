@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Quarantine states of a CronJob at reap time
+const (
+	StateNotQuarantined = "not-quarantined"
+	StatePending        = "pending" // suspended, grace period running
+	StateExpired        = "expired" // suspended past its deadline: reap deletes it
+	StateRescued        = "rescued" // unsuspended by a human during quarantine
+	StateInvalid        = "invalid" // quarantined without a readable deadline
+)
+
+// QuarantineState classifies a CronJob for reap
+func QuarantineState(cronJob *batchv1.CronJob, now time.Time) string {
+	h := detector.ParseHints(cronJob)
+	if !h.Quarantined() {
+		return StateNotQuarantined
+	}
+	if cronJob.Spec.Suspend == nil || !*cronJob.Spec.Suspend {
+		return StateRescued
+	}
+	if h.DeleteAfter == nil {
+		return StateInvalid
+	}
+	if now.Before(*h.DeleteAfter) {
+		return StatePending
+	}
+	return StateExpired
+}
+
+// Quarantine suspends a CronJob and records who quarantined it, when, why
+// and when reap may delete it. It fails if the CronJob changed since it was
+// read.
+func (r *Runner) Quarantine(ctx context.Context, cronJob *batchv1.CronJob, actor, reason string, deleteAfter, now time.Time) (*batchv1.CronJob, error) {
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": cronJob.ResourceVersion,
+			"annotations": map[string]any{
+				detector.AnnotationQuarantinedAt:    now.UTC().Format(time.RFC3339),
+				detector.AnnotationQuarantinedBy:    actor,
+				detector.AnnotationQuarantineReason: reason,
+				detector.AnnotationDeleteAfter:      deleteAfter.UTC().Format(time.RFC3339),
+				detector.AnnotationRescuedUntil:     nil,
+			},
+		},
+		"spec": map[string]any{"suspend": true},
+	}
+
+	updated, err := r.patch(ctx, cronJob, patch)
+	if err != nil {
+		return nil, fmt.Errorf("quarantine %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	if _, err := r.record(ctx, ActionQuarantine, cronJob, updated, ""); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
+
+// Rescue clears the quarantine of a CronJob a human unsuspended and keeps it
+// out of reports until rescuedUntil
+func (r *Runner) Rescue(ctx context.Context, cronJob *batchv1.CronJob, rescuedUntil time.Time) (*batchv1.CronJob, error) {
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": cronJob.ResourceVersion,
+			"annotations": map[string]any{
+				detector.AnnotationQuarantinedAt:    nil,
+				detector.AnnotationQuarantinedBy:    nil,
+				detector.AnnotationQuarantineReason: nil,
+				detector.AnnotationDeleteAfter:      nil,
+				detector.AnnotationRescuedUntil:     rescuedUntil.UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	updated, err := r.patch(ctx, cronJob, patch)
+	if err != nil {
+		return nil, fmt.Errorf("rescue %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	if _, err := r.record(ctx, ActionRescue, cronJob, updated, ""); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
+
+// patch applies a JSON merge patch. Including metadata.resourceVersion makes
+// the API server reject it with a conflict if the object changed.
+func (r *Runner) patch(ctx context.Context, cronJob *batchv1.CronJob, patch map[string]any) (*batchv1.CronJob, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return r.clientset.BatchV1().CronJobs(cronJob.Namespace).Patch(ctx, cronJob.Name, types.MergePatchType, data, metav1.PatchOptions{
+		DryRun: r.dryRunOption(),
+	})
+}
+
+// CurrentActor names the person running zombie-hunter, for audit
+// annotations: user@host when known
+func CurrentActor() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
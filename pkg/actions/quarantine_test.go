@@ -0,0 +1,142 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestQuarantineState(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	quarantinedAt := now.AddDate(0, 0, -14).Format(time.RFC3339)
+
+	tests := []struct {
+		name        string
+		suspend     bool
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			name:     "Not quarantined",
+			suspend:  true,
+			expected: StateNotQuarantined,
+		},
+		{
+			name:    "Grace period running",
+			suspend: true,
+			annotations: map[string]string{
+				detector.AnnotationQuarantinedAt: quarantinedAt,
+				detector.AnnotationDeleteAfter:   now.Add(time.Hour).Format(time.RFC3339),
+			},
+			expected: StatePending,
+		},
+		{
+			name:    "Grace period over",
+			suspend: true,
+			annotations: map[string]string{
+				detector.AnnotationQuarantinedAt: quarantinedAt,
+				detector.AnnotationDeleteAfter:   now.Add(-time.Hour).Format(time.RFC3339),
+			},
+			expected: StateExpired,
+		},
+		{
+			name:    "Unsuspended during quarantine",
+			suspend: false,
+			annotations: map[string]string{
+				detector.AnnotationQuarantinedAt: quarantinedAt,
+				detector.AnnotationDeleteAfter:   now.Add(-time.Hour).Format(time.RFC3339),
+			},
+			expected: StateRescued,
+		},
+		{
+			name:    "Deadline missing",
+			suspend: true,
+			annotations: map[string]string{
+				detector.AnnotationQuarantinedAt: quarantinedAt,
+			},
+			expected: StateInvalid,
+		},
+		{
+			name:    "Deadline unreadable",
+			suspend: true,
+			annotations: map[string]string{
+				detector.AnnotationQuarantinedAt: quarantinedAt,
+				detector.AnnotationDeleteAfter:   "next tuesday",
+			},
+			expected: StateInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cj := liveCronJob("default", "billing")
+			cj.Spec.Suspend = &tt.suspend
+			cj.Annotations = tt.annotations
+
+			if got := QuarantineState(cj, now); got != tt.expected {
+				t.Errorf("QuarantineState() = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuarantineAndRescue(t *testing.T) {
+	ctx := context.Background()
+	cj := liveCronJob("default", "billing")
+	clientset := fake.NewSimpleClientset(cj)
+	runner := NewRunner(clientset, "test", false)
+
+	now := time.Now()
+	deleteAfter := now.AddDate(0, 0, 14)
+	quarantined, err := runner.Quarantine(ctx, cj, "alice@laptop", "zombie with 90% confidence", deleteAfter, now)
+	if err != nil {
+		t.Fatalf("Quarantine: %v", err)
+	}
+
+	if quarantined.Spec.Suspend == nil || !*quarantined.Spec.Suspend {
+		t.Errorf("quarantined CronJob is not suspended")
+	}
+	if got := quarantined.Annotations[detector.AnnotationQuarantinedBy]; got != "alice@laptop" {
+		t.Errorf("quarantined-by = %q; want alice@laptop", got)
+	}
+	if quarantined.Annotations["note"] != "keep me" {
+		t.Errorf("existing annotations lost: %v", quarantined.Annotations)
+	}
+	if got := QuarantineState(quarantined, now); got != StatePending {
+		t.Errorf("state after quarantine = %q; want %q", got, StatePending)
+	}
+	if got := QuarantineState(quarantined, deleteAfter.Add(time.Minute)); got != StateExpired {
+		t.Errorf("state after the deadline = %q; want %q", got, StateExpired)
+	}
+
+	// A human unsuspends it
+	resume := false
+	quarantined.Spec.Suspend = &resume
+	resumed, err := clientset.BatchV1().CronJobs("default").Update(ctx, quarantined, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := QuarantineState(resumed, now); got != StateRescued {
+		t.Fatalf("state after unsuspending = %q; want %q", got, StateRescued)
+	}
+
+	rescued, err := runner.Rescue(ctx, resumed, now.AddDate(0, 0, 90))
+	if err != nil {
+		t.Fatalf("Rescue: %v", err)
+	}
+
+	hints := detector.ParseHints(rescued)
+	if hints.Quarantined() || hints.DeleteAfter != nil {
+		t.Errorf("quarantine annotations kept: %v", rescued.Annotations)
+	}
+	if !hints.Rescued(now) {
+		t.Errorf("rescued-until not set: %v", rescued.Annotations)
+	}
+	if got := QuarantineState(rescued, now); got != StateNotQuarantined {
+		t.Errorf("state after rescue = %q; want %q", got, StateNotQuarantined)
+	}
+}
@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrChanged is returned by Undo when the CronJob was changed by someone
+// else after the journaled action, so reverting it would lose their change
+var ErrChanged = errors.New("changed since the journaled action")
+
+// Undo applies the inverse of a journal entry: a delete is undone by
+// recreating the CronJob, a restore by deleting it, and any other change by
+// putting back the labels, annotations and suspend flag it had.
+// The undo is itself journaled.
+func (r *Runner) Undo(ctx context.Context, e *Entry) (*Entry, error) {
+	if e.Cluster != r.cluster {
+		return nil, fmt.Errorf("entry %s was recorded in cluster %s, not %s", e.ID, e.Cluster, r.cluster)
+	}
+
+	var before, after *batchv1.CronJob
+	var err error
+	switch e.Action {
+	case ActionDelete:
+		after, err = r.undoDelete(ctx, e)
+	case ActionRestore:
+		before, err = r.undoRestore(ctx, e)
+	case ActionQuarantine, ActionRescue, ActionMark, ActionUnmark:
+		before, after, err = r.undoUpdate(ctx, e)
+	case ActionUndo:
+		return nil, fmt.Errorf("entry %s is itself an undo; undo entry %s again instead", e.ID, e.Undoes)
+	default:
+		return nil, fmt.Errorf("entry %s: unknown action %q", e.ID, e.Action)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("undo %s of %s: %w", e.Action, e.Object(), err)
+	}
+
+	return r.record(ctx, ActionUndo, before, after, e.ID)
+}
+
+// undoDelete recreates a deleted CronJob unless one with its name exists
+// again
+func (r *Runner) undoDelete(ctx context.Context, e *Entry) (*batchv1.CronJob, error) {
+	cronJobs := r.clientset.BatchV1().CronJobs(e.Namespace)
+	if _, err := cronJobs.Get(ctx, e.Name, metav1.GetOptions{}); err == nil {
+		return nil, fmt.Errorf("%w: it was recreated", ErrChanged)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return cronJobs.Create(ctx, Sanitize(e.Before), metav1.CreateOptions{DryRun: r.dryRunOption()})
+}
+
+// undoRestore deletes a restored CronJob if it is still the one restored
+func (r *Runner) undoRestore(ctx context.Context, e *Entry) (*batchv1.CronJob, error) {
+	current, err := r.current(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = r.clientset.BatchV1().CronJobs(e.Namespace).Delete(ctx, e.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+		Preconditions: &metav1.Preconditions{
+			UID:             &current.UID,
+			ResourceVersion: &current.ResourceVersion,
+		},
+		DryRun: r.dryRunOption(),
+	})
+	if apierrors.IsConflict(err) {
+		return nil, fmt.Errorf("%w: %v", ErrChanged, err)
+	}
+	return current, err
+}
+
+// undoUpdate reverts the metadata and suspend flag a patch changed
+func (r *Runner) undoUpdate(ctx context.Context, e *Entry) (*batchv1.CronJob, *batchv1.CronJob, error) {
+	current, err := r.current(ctx, e)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reverted := current.DeepCopy()
+	reverted.Labels = e.Before.Labels
+	reverted.Annotations = e.Before.Annotations
+	reverted.Spec.Suspend = e.Before.Spec.Suspend
+
+	updated, err := r.clientset.BatchV1().CronJobs(e.Namespace).Update(ctx, reverted, metav1.UpdateOptions{
+		DryRun: r.dryRunOption(),
+	})
+	if apierrors.IsConflict(err) {
+		return nil, nil, fmt.Errorf("%w: %v", ErrChanged, err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, updated, nil
+}
+
+// current reads the CronJob an entry left behind and checks that nobody
+// changed it since
+func (r *Runner) current(ctx context.Context, e *Entry) (*batchv1.CronJob, error) {
+	current, err := r.clientset.BatchV1().CronJobs(e.Namespace).Get(ctx, e.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: it was deleted", ErrChanged)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current.UID != e.After.UID {
+		return nil, fmt.Errorf("%w: it was recreated (uid %s, journal has %s)", ErrChanged, current.UID, e.After.UID)
+	}
+	if current.ResourceVersion != e.After.ResourceVersion {
+		return nil, fmt.Errorf("%w: resourceVersion is %s, journal has %s", ErrChanged, current.ResourceVersion, e.After.ResourceVersion)
+	}
+	return current, nil
+}
@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package
+// +groupName=zombie-hunter.io
+// +groupGoName=ZombieHunter
+
+package v1alpha1
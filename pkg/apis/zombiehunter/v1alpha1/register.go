@@ -0,0 +1,38 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupName is the API group of the zombie-hunter custom resources
+const GroupName = "zombie-hunter.io"
+
+// SchemeGroupVersion is the group version the types are registered under
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+
+var (
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+)
+
+// Resource returns a GroupResource for an unqualified resource name
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ZombiePolicy{},
+		&ZombiePolicyList{},
+		&ClusterZombiePolicy{},
+		&ClusterZombiePolicyList{},
+		&ZombieReport{},
+		&ZombieReportList{},
+		&ClusterZombieReport{},
+		&ClusterZombieReportList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
@@ -0,0 +1,187 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Actions a policy can take on the zombies it selects
+const (
+	// ActionReport only logs zombies
+	ActionReport = "Report"
+	// ActionMark labels and annotates zombies, like --mark
+	ActionMark = "Mark"
+	// ActionQuarantine suspends zombies and deletes them after GraceDays,
+	// like quarantine followed by reap
+	ActionQuarantine = "Quarantine"
+	// ActionDelete backs up and deletes zombies
+	ActionDelete = "Delete"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ZombiePolicy configures detection for the CronJobs of its own namespace.
+// It takes precedence over every ClusterZombiePolicy.
+type ZombiePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZombiePolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ZombiePolicyList is a list of ZombiePolicies
+type ZombiePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ZombiePolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterZombiePolicy configures detection for CronJobs in every namespace
+// its NamespaceSelector matches
+type ClusterZombiePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZombiePolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterZombiePolicyList is a list of ClusterZombiePolicies
+type ClusterZombiePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterZombiePolicy `json:"items"`
+}
+
+// ZombiePolicySpec is what a policy selects and what it does with zombies
+type ZombiePolicySpec struct {
+	// ThresholdDays is the inactivity threshold; 0 uses the controller default
+	ThresholdDays int `json:"thresholdDays,omitempty"`
+	// Selector picks CronJobs by label; empty selects all of them
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// NamespaceSelector picks namespaces by label. Only used by
+	// ClusterZombiePolicy; empty selects all namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// DisabledRules are detection rules not evaluated under this policy
+	DisabledRules []string `json:"disabledRules,omitempty"`
+	// Action is Report (default), Mark, Quarantine or Delete
+	Action string `json:"action,omitempty"`
+	// MinConfidence is the confidence a zombie needs before Quarantine or
+	// Delete act on it
+	MinConfidence int `json:"minConfidence,omitempty"`
+	// GraceDays is how long Quarantine keeps a zombie suspended before
+	// deleting it; 0 uses the controller default
+	GraceDays int `json:"graceDays,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ZombieReport holds the controller's latest findings for one namespace.
+// There is one per namespace, named DefaultReportName.
+type ZombieReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ZombieReportStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ZombieReportList is a list of ZombieReports
+type ZombieReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ZombieReport `json:"items"`
+}
+
+// ZombieReportStatus lists the zombies found in a namespace
+type ZombieReportStatus struct {
+	LastScanned metav1.Time    `json:"lastScanned,omitempty"`
+	Summary     ReportSummary  `json:"summary"`
+	Zombies     []ZombieResult `json:"zombies,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterZombieReport summarizes the ZombieReports of every namespace. There
+// is one, named DefaultReportName.
+type ClusterZombieReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ClusterZombieReportStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterZombieReportList is a list of ClusterZombieReports
+type ClusterZombieReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterZombieReport `json:"items"`
+}
+
+// ClusterZombieReportStatus totals the findings of the whole cluster
+type ClusterZombieReportStatus struct {
+	LastScanned metav1.Time        `json:"lastScanned,omitempty"`
+	Summary     ReportSummary      `json:"summary"`
+	Namespaces  []NamespaceSummary `json:"namespaces,omitempty"`
+}
+
+// DefaultReportName is the name of the reports the controller writes
+const DefaultReportName = "zombie-hunter"
+
+// ReportSummary counts evaluated CronJobs
+type ReportSummary struct {
+	CronJobs     int `json:"cronJobs"`
+	Zombies      int `json:"zombies"`
+	Acknowledged int `json:"acknowledged"`
+}
+
+// NamespaceSummary is one namespace's line in the cluster report
+type NamespaceSummary struct {
+	Namespace     string `json:"namespace"`
+	ReportSummary `json:",inline"`
+}
+
+// ZombieResult is the verdict on one zombie or acknowledged CronJob
+type ZombieResult struct {
+	Name             string         `json:"name"`
+	Confidence       int            `json:"confidence"`
+	DaysSinceSuccess int            `json:"daysSinceSuccess"`
+	ExpectedRuns     int            `json:"expectedRuns,omitempty"`
+	MissedRuns       int            `json:"missedRuns,omitempty"`
+	LastSuccessTime  *metav1.Time   `json:"lastSuccessTime,omitempty"`
+	Acknowledged     bool           `json:"acknowledged,omitempty"`
+	Owner            string         `json:"owner,omitempty"`
+	OwnerSource      string         `json:"ownerSource,omitempty"`
+	ManagedBy        string         `json:"managedBy,omitempty"`
+	LastModifiedBy   string         `json:"lastModifiedBy,omitempty"`
+	GitOpsSource     string         `json:"gitOpsSource,omitempty"` // set when Argo CD, Flux or Helm would recreate the CronJob
+	SuggestedAction  string         `json:"suggestedAction,omitempty"`
+	Policy           string         `json:"policy,omitempty"`
+	Signals          []ZombieSignal `json:"signals,omitempty"`
+}
+
+// ZombieSignal is one piece of evidence behind a verdict
+type ZombieSignal struct {
+	Name     string `json:"name"`
+	Rule     string `json:"rule,omitempty"`
+	Weight   int    `json:"weight"`
+	Observed string `json:"observed,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
@@ -0,0 +1,54 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	scheme "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterZombiePoliciesGetter has a method to return a ClusterZombiePolicyInterface.
+// A group's client should implement this interface.
+type ClusterZombiePoliciesGetter interface {
+	ClusterZombiePolicies() ClusterZombiePolicyInterface
+}
+
+// ClusterZombiePolicyInterface has methods to work with ClusterZombiePolicy resources.
+type ClusterZombiePolicyInterface interface {
+	Create(ctx context.Context, clusterZombiePolicy *zombiehunterv1alpha1.ClusterZombiePolicy, opts v1.CreateOptions) (*zombiehunterv1alpha1.ClusterZombiePolicy, error)
+	Update(ctx context.Context, clusterZombiePolicy *zombiehunterv1alpha1.ClusterZombiePolicy, opts v1.UpdateOptions) (*zombiehunterv1alpha1.ClusterZombiePolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*zombiehunterv1alpha1.ClusterZombiePolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*zombiehunterv1alpha1.ClusterZombiePolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *zombiehunterv1alpha1.ClusterZombiePolicy, err error)
+	ClusterZombiePolicyExpansion
+}
+
+// clusterZombiePolicies implements ClusterZombiePolicyInterface
+type clusterZombiePolicies struct {
+	*gentype.ClientWithList[*zombiehunterv1alpha1.ClusterZombiePolicy, *zombiehunterv1alpha1.ClusterZombiePolicyList]
+}
+
+// newClusterZombiePolicies returns a ClusterZombiePolicies
+func newClusterZombiePolicies(c *ZombieHunterV1alpha1Client) *clusterZombiePolicies {
+	return &clusterZombiePolicies{
+		gentype.NewClientWithList[*zombiehunterv1alpha1.ClusterZombiePolicy, *zombiehunterv1alpha1.ClusterZombiePolicyList](
+			"clusterzombiepolicies",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *zombiehunterv1alpha1.ClusterZombiePolicy { return &zombiehunterv1alpha1.ClusterZombiePolicy{} },
+			func() *zombiehunterv1alpha1.ClusterZombiePolicyList {
+				return &zombiehunterv1alpha1.ClusterZombiePolicyList{}
+			},
+		),
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	scheme "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterZombieReportsGetter has a method to return a ClusterZombieReportInterface.
+// A group's client should implement this interface.
+type ClusterZombieReportsGetter interface {
+	ClusterZombieReports() ClusterZombieReportInterface
+}
+
+// ClusterZombieReportInterface has methods to work with ClusterZombieReport resources.
+type ClusterZombieReportInterface interface {
+	Create(ctx context.Context, clusterZombieReport *zombiehunterv1alpha1.ClusterZombieReport, opts v1.CreateOptions) (*zombiehunterv1alpha1.ClusterZombieReport, error)
+	Update(ctx context.Context, clusterZombieReport *zombiehunterv1alpha1.ClusterZombieReport, opts v1.UpdateOptions) (*zombiehunterv1alpha1.ClusterZombieReport, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterZombieReport *zombiehunterv1alpha1.ClusterZombieReport, opts v1.UpdateOptions) (*zombiehunterv1alpha1.ClusterZombieReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*zombiehunterv1alpha1.ClusterZombieReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*zombiehunterv1alpha1.ClusterZombieReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *zombiehunterv1alpha1.ClusterZombieReport, err error)
+	ClusterZombieReportExpansion
+}
+
+// clusterZombieReports implements ClusterZombieReportInterface
+type clusterZombieReports struct {
+	*gentype.ClientWithList[*zombiehunterv1alpha1.ClusterZombieReport, *zombiehunterv1alpha1.ClusterZombieReportList]
+}
+
+// newClusterZombieReports returns a ClusterZombieReports
+func newClusterZombieReports(c *ZombieHunterV1alpha1Client) *clusterZombieReports {
+	return &clusterZombieReports{
+		gentype.NewClientWithList[*zombiehunterv1alpha1.ClusterZombieReport, *zombiehunterv1alpha1.ClusterZombieReportList](
+			"clusterzombiereports",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *zombiehunterv1alpha1.ClusterZombieReport { return &zombiehunterv1alpha1.ClusterZombieReport{} },
+			func() *zombiehunterv1alpha1.ClusterZombieReportList {
+				return &zombiehunterv1alpha1.ClusterZombieReportList{}
+			},
+		),
+	}
+}
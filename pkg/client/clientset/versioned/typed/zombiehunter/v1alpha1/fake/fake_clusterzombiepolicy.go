@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/typed/zombiehunter/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterZombiePolicies implements ClusterZombiePolicyInterface
+type fakeClusterZombiePolicies struct {
+	*gentype.FakeClientWithList[*v1alpha1.ClusterZombiePolicy, *v1alpha1.ClusterZombiePolicyList]
+	Fake *FakeZombieHunterV1alpha1
+}
+
+func newFakeClusterZombiePolicies(fake *FakeZombieHunterV1alpha1) zombiehunterv1alpha1.ClusterZombiePolicyInterface {
+	return &fakeClusterZombiePolicies{
+		gentype.NewFakeClientWithList[*v1alpha1.ClusterZombiePolicy, *v1alpha1.ClusterZombiePolicyList](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusterzombiepolicies"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterZombiePolicy"),
+			func() *v1alpha1.ClusterZombiePolicy { return &v1alpha1.ClusterZombiePolicy{} },
+			func() *v1alpha1.ClusterZombiePolicyList { return &v1alpha1.ClusterZombiePolicyList{} },
+			func(dst, src *v1alpha1.ClusterZombiePolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterZombiePolicyList) []*v1alpha1.ClusterZombiePolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterZombiePolicyList, items []*v1alpha1.ClusterZombiePolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
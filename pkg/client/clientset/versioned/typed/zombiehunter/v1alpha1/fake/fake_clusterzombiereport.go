@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/typed/zombiehunter/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterZombieReports implements ClusterZombieReportInterface
+type fakeClusterZombieReports struct {
+	*gentype.FakeClientWithList[*v1alpha1.ClusterZombieReport, *v1alpha1.ClusterZombieReportList]
+	Fake *FakeZombieHunterV1alpha1
+}
+
+func newFakeClusterZombieReports(fake *FakeZombieHunterV1alpha1) zombiehunterv1alpha1.ClusterZombieReportInterface {
+	return &fakeClusterZombieReports{
+		gentype.NewFakeClientWithList[*v1alpha1.ClusterZombieReport, *v1alpha1.ClusterZombieReportList](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusterzombiereports"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterZombieReport"),
+			func() *v1alpha1.ClusterZombieReport { return &v1alpha1.ClusterZombieReport{} },
+			func() *v1alpha1.ClusterZombieReportList { return &v1alpha1.ClusterZombieReportList{} },
+			func(dst, src *v1alpha1.ClusterZombieReportList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterZombieReportList) []*v1alpha1.ClusterZombieReport {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterZombieReportList, items []*v1alpha1.ClusterZombieReport) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
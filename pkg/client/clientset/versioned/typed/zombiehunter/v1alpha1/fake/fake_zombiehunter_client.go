@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/typed/zombiehunter/v1alpha1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeZombieHunterV1alpha1 struct {
+	*testing.Fake
+}
+
+func (c *FakeZombieHunterV1alpha1) ClusterZombiePolicies() v1alpha1.ClusterZombiePolicyInterface {
+	return newFakeClusterZombiePolicies(c)
+}
+
+func (c *FakeZombieHunterV1alpha1) ClusterZombieReports() v1alpha1.ClusterZombieReportInterface {
+	return newFakeClusterZombieReports(c)
+}
+
+func (c *FakeZombieHunterV1alpha1) ZombiePolicies(namespace string) v1alpha1.ZombiePolicyInterface {
+	return newFakeZombiePolicies(c, namespace)
+}
+
+func (c *FakeZombieHunterV1alpha1) ZombieReports(namespace string) v1alpha1.ZombieReportInterface {
+	return newFakeZombieReports(c, namespace)
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeZombieHunterV1alpha1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
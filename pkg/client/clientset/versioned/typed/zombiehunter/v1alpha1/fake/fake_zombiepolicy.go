@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/typed/zombiehunter/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeZombiePolicies implements ZombiePolicyInterface
+type fakeZombiePolicies struct {
+	*gentype.FakeClientWithList[*v1alpha1.ZombiePolicy, *v1alpha1.ZombiePolicyList]
+	Fake *FakeZombieHunterV1alpha1
+}
+
+func newFakeZombiePolicies(fake *FakeZombieHunterV1alpha1, namespace string) zombiehunterv1alpha1.ZombiePolicyInterface {
+	return &fakeZombiePolicies{
+		gentype.NewFakeClientWithList[*v1alpha1.ZombiePolicy, *v1alpha1.ZombiePolicyList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("zombiepolicies"),
+			v1alpha1.SchemeGroupVersion.WithKind("ZombiePolicy"),
+			func() *v1alpha1.ZombiePolicy { return &v1alpha1.ZombiePolicy{} },
+			func() *v1alpha1.ZombiePolicyList { return &v1alpha1.ZombiePolicyList{} },
+			func(dst, src *v1alpha1.ZombiePolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ZombiePolicyList) []*v1alpha1.ZombiePolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ZombiePolicyList, items []*v1alpha1.ZombiePolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/typed/zombiehunter/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeZombieReports implements ZombieReportInterface
+type fakeZombieReports struct {
+	*gentype.FakeClientWithList[*v1alpha1.ZombieReport, *v1alpha1.ZombieReportList]
+	Fake *FakeZombieHunterV1alpha1
+}
+
+func newFakeZombieReports(fake *FakeZombieHunterV1alpha1, namespace string) zombiehunterv1alpha1.ZombieReportInterface {
+	return &fakeZombieReports{
+		gentype.NewFakeClientWithList[*v1alpha1.ZombieReport, *v1alpha1.ZombieReportList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("zombiereports"),
+			v1alpha1.SchemeGroupVersion.WithKind("ZombieReport"),
+			func() *v1alpha1.ZombieReport { return &v1alpha1.ZombieReport{} },
+			func() *v1alpha1.ZombieReportList { return &v1alpha1.ZombieReportList{} },
+			func(dst, src *v1alpha1.ZombieReportList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ZombieReportList) []*v1alpha1.ZombieReport {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ZombieReportList, items []*v1alpha1.ZombieReport) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
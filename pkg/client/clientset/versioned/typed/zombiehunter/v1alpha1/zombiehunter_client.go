@@ -0,0 +1,100 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	http "net/http"
+
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	scheme "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type ZombieHunterV1alpha1Interface interface {
+	RESTClient() rest.Interface
+	ClusterZombiePoliciesGetter
+	ClusterZombieReportsGetter
+	ZombiePoliciesGetter
+	ZombieReportsGetter
+}
+
+// ZombieHunterV1alpha1Client is used to interact with features provided by the zombie-hunter.io group.
+type ZombieHunterV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+func (c *ZombieHunterV1alpha1Client) ClusterZombiePolicies() ClusterZombiePolicyInterface {
+	return newClusterZombiePolicies(c)
+}
+
+func (c *ZombieHunterV1alpha1Client) ClusterZombieReports() ClusterZombieReportInterface {
+	return newClusterZombieReports(c)
+}
+
+func (c *ZombieHunterV1alpha1Client) ZombiePolicies(namespace string) ZombiePolicyInterface {
+	return newZombiePolicies(c, namespace)
+}
+
+func (c *ZombieHunterV1alpha1Client) ZombieReports(namespace string) ZombieReportInterface {
+	return newZombieReports(c, namespace)
+}
+
+// NewForConfig creates a new ZombieHunterV1alpha1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*ZombieHunterV1alpha1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new ZombieHunterV1alpha1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*ZombieHunterV1alpha1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &ZombieHunterV1alpha1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new ZombieHunterV1alpha1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *ZombieHunterV1alpha1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new ZombieHunterV1alpha1Client for the given RESTClient.
+func New(c rest.Interface) *ZombieHunterV1alpha1Client {
+	return &ZombieHunterV1alpha1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) {
+	gv := zombiehunterv1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = rest.CodecFactoryForGeneratedClient(scheme.Scheme, scheme.Codecs).WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *ZombieHunterV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,52 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	scheme "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ZombiePoliciesGetter has a method to return a ZombiePolicyInterface.
+// A group's client should implement this interface.
+type ZombiePoliciesGetter interface {
+	ZombiePolicies(namespace string) ZombiePolicyInterface
+}
+
+// ZombiePolicyInterface has methods to work with ZombiePolicy resources.
+type ZombiePolicyInterface interface {
+	Create(ctx context.Context, zombiePolicy *zombiehunterv1alpha1.ZombiePolicy, opts v1.CreateOptions) (*zombiehunterv1alpha1.ZombiePolicy, error)
+	Update(ctx context.Context, zombiePolicy *zombiehunterv1alpha1.ZombiePolicy, opts v1.UpdateOptions) (*zombiehunterv1alpha1.ZombiePolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*zombiehunterv1alpha1.ZombiePolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*zombiehunterv1alpha1.ZombiePolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *zombiehunterv1alpha1.ZombiePolicy, err error)
+	ZombiePolicyExpansion
+}
+
+// zombiePolicies implements ZombiePolicyInterface
+type zombiePolicies struct {
+	*gentype.ClientWithList[*zombiehunterv1alpha1.ZombiePolicy, *zombiehunterv1alpha1.ZombiePolicyList]
+}
+
+// newZombiePolicies returns a ZombiePolicies
+func newZombiePolicies(c *ZombieHunterV1alpha1Client, namespace string) *zombiePolicies {
+	return &zombiePolicies{
+		gentype.NewClientWithList[*zombiehunterv1alpha1.ZombiePolicy, *zombiehunterv1alpha1.ZombiePolicyList](
+			"zombiepolicies",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *zombiehunterv1alpha1.ZombiePolicy { return &zombiehunterv1alpha1.ZombiePolicy{} },
+			func() *zombiehunterv1alpha1.ZombiePolicyList { return &zombiehunterv1alpha1.ZombiePolicyList{} },
+		),
+	}
+}
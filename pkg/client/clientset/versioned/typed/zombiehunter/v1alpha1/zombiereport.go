@@ -0,0 +1,54 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	scheme "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ZombieReportsGetter has a method to return a ZombieReportInterface.
+// A group's client should implement this interface.
+type ZombieReportsGetter interface {
+	ZombieReports(namespace string) ZombieReportInterface
+}
+
+// ZombieReportInterface has methods to work with ZombieReport resources.
+type ZombieReportInterface interface {
+	Create(ctx context.Context, zombieReport *zombiehunterv1alpha1.ZombieReport, opts v1.CreateOptions) (*zombiehunterv1alpha1.ZombieReport, error)
+	Update(ctx context.Context, zombieReport *zombiehunterv1alpha1.ZombieReport, opts v1.UpdateOptions) (*zombiehunterv1alpha1.ZombieReport, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, zombieReport *zombiehunterv1alpha1.ZombieReport, opts v1.UpdateOptions) (*zombiehunterv1alpha1.ZombieReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*zombiehunterv1alpha1.ZombieReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*zombiehunterv1alpha1.ZombieReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *zombiehunterv1alpha1.ZombieReport, err error)
+	ZombieReportExpansion
+}
+
+// zombieReports implements ZombieReportInterface
+type zombieReports struct {
+	*gentype.ClientWithList[*zombiehunterv1alpha1.ZombieReport, *zombiehunterv1alpha1.ZombieReportList]
+}
+
+// newZombieReports returns a ZombieReports
+func newZombieReports(c *ZombieHunterV1alpha1Client, namespace string) *zombieReports {
+	return &zombieReports{
+		gentype.NewClientWithList[*zombiehunterv1alpha1.ZombieReport, *zombiehunterv1alpha1.ZombieReportList](
+			"zombiereports",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *zombiehunterv1alpha1.ZombieReport { return &zombiehunterv1alpha1.ZombieReport{} },
+			func() *zombiehunterv1alpha1.ZombieReportList { return &zombiehunterv1alpha1.ZombieReportList{} },
+		),
+	}
+}
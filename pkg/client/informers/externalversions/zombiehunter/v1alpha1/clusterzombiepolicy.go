@@ -0,0 +1,85 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apiszombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	versioned "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/rrdesai64/zombie-hunter/pkg/client/informers/externalversions/internalinterfaces"
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/listers/zombiehunter/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterZombiePolicyInformer provides access to a shared informer and lister for
+// ClusterZombiePolicies.
+type ClusterZombiePolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() zombiehunterv1alpha1.ClusterZombiePolicyLister
+}
+
+type clusterZombiePolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterZombiePolicyInformer constructs a new informer for ClusterZombiePolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterZombiePolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterZombiePolicyInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterZombiePolicyInformer constructs a new informer for ClusterZombiePolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterZombiePolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ClusterZombiePolicies().List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ClusterZombiePolicies().Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ClusterZombiePolicies().List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ClusterZombiePolicies().Watch(ctx, options)
+			},
+		},
+		&apiszombiehunterv1alpha1.ClusterZombiePolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterZombiePolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterZombiePolicyInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterZombiePolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiszombiehunterv1alpha1.ClusterZombiePolicy{}, f.defaultInformer)
+}
+
+func (f *clusterZombiePolicyInformer) Lister() zombiehunterv1alpha1.ClusterZombiePolicyLister {
+	return zombiehunterv1alpha1.NewClusterZombiePolicyLister(f.Informer().GetIndexer())
+}
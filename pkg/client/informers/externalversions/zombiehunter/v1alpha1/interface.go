@@ -0,0 +1,50 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/rrdesai64/zombie-hunter/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ClusterZombiePolicies returns a ClusterZombiePolicyInformer.
+	ClusterZombiePolicies() ClusterZombiePolicyInformer
+	// ClusterZombieReports returns a ClusterZombieReportInformer.
+	ClusterZombieReports() ClusterZombieReportInformer
+	// ZombiePolicies returns a ZombiePolicyInformer.
+	ZombiePolicies() ZombiePolicyInformer
+	// ZombieReports returns a ZombieReportInformer.
+	ZombieReports() ZombieReportInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ClusterZombiePolicies returns a ClusterZombiePolicyInformer.
+func (v *version) ClusterZombiePolicies() ClusterZombiePolicyInformer {
+	return &clusterZombiePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterZombieReports returns a ClusterZombieReportInformer.
+func (v *version) ClusterZombieReports() ClusterZombieReportInformer {
+	return &clusterZombieReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ZombiePolicies returns a ZombiePolicyInformer.
+func (v *version) ZombiePolicies() ZombiePolicyInformer {
+	return &zombiePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ZombieReports returns a ZombieReportInformer.
+func (v *version) ZombieReports() ZombieReportInformer {
+	return &zombieReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,86 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apiszombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	versioned "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/rrdesai64/zombie-hunter/pkg/client/informers/externalversions/internalinterfaces"
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/client/listers/zombiehunter/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ZombieReportInformer provides access to a shared informer and lister for
+// ZombieReports.
+type ZombieReportInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() zombiehunterv1alpha1.ZombieReportLister
+}
+
+type zombieReportInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewZombieReportInformer constructs a new informer for ZombieReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewZombieReportInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredZombieReportInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredZombieReportInformer constructs a new informer for ZombieReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredZombieReportInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ZombieReports(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ZombieReports(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ZombieReports(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ZombieHunterV1alpha1().ZombieReports(namespace).Watch(ctx, options)
+			},
+		},
+		&apiszombiehunterv1alpha1.ZombieReport{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *zombieReportInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredZombieReportInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *zombieReportInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiszombiehunterv1alpha1.ZombieReport{}, f.defaultInformer)
+}
+
+func (f *zombieReportInformer) Lister() zombiehunterv1alpha1.ZombieReportLister {
+	return zombiehunterv1alpha1.NewZombieReportLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,32 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterZombiePolicyLister helps list ClusterZombiePolicies.
+// All objects returned here must be treated as read-only.
+type ClusterZombiePolicyLister interface {
+	// List lists all ClusterZombiePolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*zombiehunterv1alpha1.ClusterZombiePolicy, err error)
+	// Get retrieves the ClusterZombiePolicy from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*zombiehunterv1alpha1.ClusterZombiePolicy, error)
+	ClusterZombiePolicyListerExpansion
+}
+
+// clusterZombiePolicyLister implements the ClusterZombiePolicyLister interface.
+type clusterZombiePolicyLister struct {
+	listers.ResourceIndexer[*zombiehunterv1alpha1.ClusterZombiePolicy]
+}
+
+// NewClusterZombiePolicyLister returns a new ClusterZombiePolicyLister.
+func NewClusterZombiePolicyLister(indexer cache.Indexer) ClusterZombiePolicyLister {
+	return &clusterZombiePolicyLister{listers.New[*zombiehunterv1alpha1.ClusterZombiePolicy](indexer, zombiehunterv1alpha1.Resource("clusterzombiepolicy"))}
+}
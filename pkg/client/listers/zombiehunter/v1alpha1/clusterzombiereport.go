@@ -0,0 +1,32 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterZombieReportLister helps list ClusterZombieReports.
+// All objects returned here must be treated as read-only.
+type ClusterZombieReportLister interface {
+	// List lists all ClusterZombieReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*zombiehunterv1alpha1.ClusterZombieReport, err error)
+	// Get retrieves the ClusterZombieReport from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*zombiehunterv1alpha1.ClusterZombieReport, error)
+	ClusterZombieReportListerExpansion
+}
+
+// clusterZombieReportLister implements the ClusterZombieReportLister interface.
+type clusterZombieReportLister struct {
+	listers.ResourceIndexer[*zombiehunterv1alpha1.ClusterZombieReport]
+}
+
+// NewClusterZombieReportLister returns a new ClusterZombieReportLister.
+func NewClusterZombieReportLister(indexer cache.Indexer) ClusterZombieReportLister {
+	return &clusterZombieReportLister{listers.New[*zombiehunterv1alpha1.ClusterZombieReport](indexer, zombiehunterv1alpha1.Resource("clusterzombiereport"))}
+}
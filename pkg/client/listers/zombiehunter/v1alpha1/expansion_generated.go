@@ -0,0 +1,27 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterZombiePolicyListerExpansion allows custom methods to be added to
+// ClusterZombiePolicyLister.
+type ClusterZombiePolicyListerExpansion interface{}
+
+// ClusterZombieReportListerExpansion allows custom methods to be added to
+// ClusterZombieReportLister.
+type ClusterZombieReportListerExpansion interface{}
+
+// ZombiePolicyListerExpansion allows custom methods to be added to
+// ZombiePolicyLister.
+type ZombiePolicyListerExpansion interface{}
+
+// ZombiePolicyNamespaceListerExpansion allows custom methods to be added to
+// ZombiePolicyNamespaceLister.
+type ZombiePolicyNamespaceListerExpansion interface{}
+
+// ZombieReportListerExpansion allows custom methods to be added to
+// ZombieReportLister.
+type ZombieReportListerExpansion interface{}
+
+// ZombieReportNamespaceListerExpansion allows custom methods to be added to
+// ZombieReportNamespaceLister.
+type ZombieReportNamespaceListerExpansion interface{}
@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ZombiePolicyLister helps list ZombiePolicies.
+// All objects returned here must be treated as read-only.
+type ZombiePolicyLister interface {
+	// List lists all ZombiePolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*zombiehunterv1alpha1.ZombiePolicy, err error)
+	// ZombiePolicies returns an object that can list and get ZombiePolicies.
+	ZombiePolicies(namespace string) ZombiePolicyNamespaceLister
+	ZombiePolicyListerExpansion
+}
+
+// zombiePolicyLister implements the ZombiePolicyLister interface.
+type zombiePolicyLister struct {
+	listers.ResourceIndexer[*zombiehunterv1alpha1.ZombiePolicy]
+}
+
+// NewZombiePolicyLister returns a new ZombiePolicyLister.
+func NewZombiePolicyLister(indexer cache.Indexer) ZombiePolicyLister {
+	return &zombiePolicyLister{listers.New[*zombiehunterv1alpha1.ZombiePolicy](indexer, zombiehunterv1alpha1.Resource("zombiepolicy"))}
+}
+
+// ZombiePolicies returns an object that can list and get ZombiePolicies.
+func (s *zombiePolicyLister) ZombiePolicies(namespace string) ZombiePolicyNamespaceLister {
+	return zombiePolicyNamespaceLister{listers.NewNamespaced[*zombiehunterv1alpha1.ZombiePolicy](s.ResourceIndexer, namespace)}
+}
+
+// ZombiePolicyNamespaceLister helps list and get ZombiePolicies.
+// All objects returned here must be treated as read-only.
+type ZombiePolicyNamespaceLister interface {
+	// List lists all ZombiePolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*zombiehunterv1alpha1.ZombiePolicy, err error)
+	// Get retrieves the ZombiePolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*zombiehunterv1alpha1.ZombiePolicy, error)
+	ZombiePolicyNamespaceListerExpansion
+}
+
+// zombiePolicyNamespaceLister implements the ZombiePolicyNamespaceLister
+// interface.
+type zombiePolicyNamespaceLister struct {
+	listers.ResourceIndexer[*zombiehunterv1alpha1.ZombiePolicy]
+}
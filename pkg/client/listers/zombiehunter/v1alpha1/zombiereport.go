@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	zombiehunterv1alpha1 "github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ZombieReportLister helps list ZombieReports.
+// All objects returned here must be treated as read-only.
+type ZombieReportLister interface {
+	// List lists all ZombieReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*zombiehunterv1alpha1.ZombieReport, err error)
+	// ZombieReports returns an object that can list and get ZombieReports.
+	ZombieReports(namespace string) ZombieReportNamespaceLister
+	ZombieReportListerExpansion
+}
+
+// zombieReportLister implements the ZombieReportLister interface.
+type zombieReportLister struct {
+	listers.ResourceIndexer[*zombiehunterv1alpha1.ZombieReport]
+}
+
+// NewZombieReportLister returns a new ZombieReportLister.
+func NewZombieReportLister(indexer cache.Indexer) ZombieReportLister {
+	return &zombieReportLister{listers.New[*zombiehunterv1alpha1.ZombieReport](indexer, zombiehunterv1alpha1.Resource("zombiereport"))}
+}
+
+// ZombieReports returns an object that can list and get ZombieReports.
+func (s *zombieReportLister) ZombieReports(namespace string) ZombieReportNamespaceLister {
+	return zombieReportNamespaceLister{listers.NewNamespaced[*zombiehunterv1alpha1.ZombieReport](s.ResourceIndexer, namespace)}
+}
+
+// ZombieReportNamespaceLister helps list and get ZombieReports.
+// All objects returned here must be treated as read-only.
+type ZombieReportNamespaceLister interface {
+	// List lists all ZombieReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*zombiehunterv1alpha1.ZombieReport, err error)
+	// Get retrieves the ZombieReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*zombiehunterv1alpha1.ZombieReport, error)
+	ZombieReportNamespaceListerExpansion
+}
+
+// zombieReportNamespaceLister implements the ZombieReportNamespaceLister
+// interface.
+type zombieReportNamespaceLister struct {
+	listers.ResourceIndexer[*zombiehunterv1alpha1.ZombieReport]
+}
@@ -0,0 +1,556 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/cost"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// FileName is the config file looked for in the working directory
+const FileName = "zombie-hunter.yaml"
+
+// EnvPrefix prefixes environment variables that override config values
+const EnvPrefix = "ZOMBIE_HUNTER_"
+
+// Config holds every scan setting. It is loaded from a file, then overridden
+// by flags and finally by ZOMBIE_HUNTER_* environment variables.
+type Config struct {
+	Clusters      Clusters                         `json:"clusters"`
+	Thresholds    Thresholds                       `json:"thresholds"`
+	Namespaces    Namespaces                       `json:"namespaces"`
+	Selector      string                           `json:"selector,omitempty"`
+	Output        Output                           `json:"output"`
+	Scan          Scan                             `json:"scan"`
+	Backup        Backup                           `json:"backup"`
+	Quarantine    Quarantine                       `json:"quarantine"`
+	Journal       Journal                          `json:"journal"`
+	Policy        string                           `json:"policy,omitempty"`
+	Rules         map[string]detector.RuleSettings `json:"rules,omitempty"`
+	Owners        Owners                           `json:"owners"`
+	Cost          Cost                             `json:"cost"`
+	Notifications []Notification                   `json:"notifications,omitempty"`
+}
+
+// Clusters selects the clusters to scan. With no contexts the current
+// kubeconfig context (or the in-cluster config) is used.
+type Clusters struct {
+	// Kubeconfig overrides $KUBECONFIG and ~/.kube/config
+	Kubeconfig  string   `json:"kubeconfig,omitempty"`
+	Contexts    []string `json:"contexts,omitempty"`
+	AllContexts bool     `json:"allContexts,omitempty"`
+	// Concurrency is how many clusters are scanned at once
+	Concurrency int `json:"concurrency"`
+}
+
+// Thresholds control when a CronJob counts as a zombie
+type Thresholds struct {
+	Days int `json:"days"`
+}
+
+// Namespaces selects which namespaces are scanned. Entries may be globs
+// such as "kube-*"; an empty include list means all namespaces. Selector
+// picks namespaces by their labels.
+type Namespaces struct {
+	Include  []string `json:"include,omitempty"`
+	Exclude  []string `json:"exclude,omitempty"`
+	Selector string   `json:"selector,omitempty"`
+}
+
+// Scan controls how objects are read from the API server
+type Scan struct {
+	// PageSize is how many objects each List call returns
+	PageSize int64 `json:"pageSize"`
+	// FullJobs keeps complete Job objects, for policies that read Job specs
+	FullJobs bool `json:"fullJobs,omitempty"`
+	// Mark labels and annotates zombies in the cluster and clears the marks
+	// of CronJobs that recovered
+	Mark bool `json:"mark,omitempty"`
+}
+
+// Backup says where CronJobs are saved before they are changed. With a
+// namespace backups are ConfigMaps in the cluster, otherwise files in Dir.
+type Backup struct {
+	Dir       string `json:"dir,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Journal says where every change zombie-hunter makes is recorded. With a
+// namespace entries are ConfigMaps in the cluster, otherwise lines in Path.
+type Journal struct {
+	Path      string `json:"path,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Quarantine controls the suspend-then-delete workflow
+type Quarantine struct {
+	// GraceDays is how long a quarantined CronJob stays suspended before
+	// reap deletes it
+	GraceDays int `json:"graceDays"`
+	// RescueDays is how long a CronJob unsuspended during quarantine is
+	// left out of reports
+	RescueDays int `json:"rescueDays"`
+}
+
+// Output controls how reports are written
+type Output struct {
+	Format string `json:"format"`
+	// File is written atomically instead of printing the report; empty
+	// prints to stdout
+	File string `json:"file,omitempty"`
+}
+
+// Owners configures how the team responsible for a CronJob is found
+type Owners struct {
+	// Keys are labels and annotations naming an owner, looked up on the
+	// CronJob and then on its namespace
+	Keys []string `json:"keys"`
+	// Mappings assign namespaces (names or globs) to owners when no key
+	// matched; MappingFile holds more of them
+	Mappings    []owner.Mapping `json:"mappings,omitempty"`
+	MappingFile string          `json:"mappingFile,omitempty"`
+}
+
+// Cost configures the monthly cost estimate of each CronJob
+type Cost struct {
+	Prices cost.PriceSheet `json:"prices"`
+	// DefaultRunMinutes is assumed for CronJobs with no finished Jobs
+	// retained
+	DefaultRunMinutes int `json:"defaultRunMinutes"`
+}
+
+// Notification is a target that scan results are sent to
+type Notification struct {
+	Type string `json:"type"`
+	// URL is the Slack or webhook URL, or smtp://[user:password@]host[:port]
+	// for email
+	URL string `json:"url,omitempty"`
+	// From and To address emails
+	From string   `json:"from,omitempty"`
+	To   []string `json:"to,omitempty"`
+	// Secret signs webhook requests with HMAC-SHA256
+	Secret string `json:"secret,omitempty"`
+	// MinConfidence, Namespaces (names or globs) and Owners select the
+	// zombies sent to this target; "unowned" selects those without an owner
+	MinConfidence int      `json:"minConfidence,omitempty"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	Owners        []string `json:"owners,omitempty"`
+	// SplitByOwner sends one message per owner instead of one in total
+	SplitByOwner bool `json:"splitByOwner,omitempty"`
+}
+
+// Output formats understood by the report package
+var formats = []string{"table", "csv", "json", "prometheus", "openmetrics"}
+
+// Notification types
+var notificationTypes = []string{"slack", "webhook", "email"}
+
+// Default returns the built-in settings
+func Default() *Config {
+	return &Config{
+		Thresholds: Thresholds{Days: 30},
+		Output:     Output{Format: "table"},
+		Scan:       Scan{PageSize: k8s.DefaultPageSize},
+		Owners:     Owners{Keys: owner.DefaultKeys},
+		Cost:       Cost{Prices: cost.DefaultPrices, DefaultRunMinutes: int(cost.DefaultRunDuration.Minutes())},
+		Clusters:   Clusters{Concurrency: fleet.DefaultConcurrency},
+		Backup:     Backup{Dir: filepath.Join(dataDir(), "backups")},
+		Journal:    Journal{Path: filepath.Join(dataDir(), "journal.jsonl")},
+		Quarantine: Quarantine{GraceDays: 14, RescueDays: 90},
+	}
+}
+
+// Discover returns the config file to use when --config is not given: the
+// working directory first, then $XDG_CONFIG_HOME/zombie-hunter/config.yaml
+// (~/.config when unset). It returns "" when there is none.
+func Discover() string {
+	candidates := []string{FileName}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		candidates = append(candidates,
+			filepath.Join(configHome, "zombie-hunter", "config.yaml"),
+			filepath.Join(configHome, "zombie-hunter", FileName),
+		)
+	}
+
+	for _, c := range candidates {
+		if info, err := os.Stat(c); err == nil && !info.IsDir() {
+			return c
+		}
+	}
+	return ""
+}
+
+// Load reads a config file on top of the defaults. An empty path returns
+// the defaults.
+func Load(file string) (*Config, error) {
+	cfg := Default()
+	if file == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	// Relative paths are relative to the config file
+	for _, p := range []*string{&cfg.Policy, &cfg.Clusters.Kubeconfig, &cfg.Backup.Dir, &cfg.Journal.Path, &cfg.Owners.MappingFile} {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(filepath.Dir(file), *p)
+		}
+	}
+
+	// An empty path in the file means the default, not the working directory
+	defaults := Default()
+	if cfg.Backup.Dir == "" {
+		cfg.Backup.Dir = defaults.Backup.Dir
+	}
+	if cfg.Journal.Path == "" {
+		cfg.Journal.Path = defaults.Journal.Path
+	}
+
+	return cfg, nil
+}
+
+// ApplyEnv overrides settings from ZOMBIE_HUNTER_* environment variables
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	if v, ok := lookup(EnvPrefix + "KUBECONFIG"); ok {
+		c.Clusters.Kubeconfig = v
+	}
+	// Like their flags, CONTEXTS and ALL_CONTEXTS replace each other
+	if v, ok := lookup(EnvPrefix + "CONTEXTS"); ok {
+		c.Clusters.Contexts = splitList(v)
+		c.Clusters.AllContexts = false
+	}
+	if v, ok := lookup(EnvPrefix + "ALL_CONTEXTS"); ok {
+		all, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%sALL_CONTEXTS: %w", EnvPrefix, err)
+		}
+		c.Clusters.AllContexts = all
+		if all {
+			c.Clusters.Contexts = nil
+		}
+	}
+	if v, ok := lookup(EnvPrefix + "CONCURRENCY"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sCONCURRENCY: %w", EnvPrefix, err)
+		}
+		c.Clusters.Concurrency = n
+	}
+	if v, ok := lookup(EnvPrefix + "DAYS"); ok {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sDAYS: %w", EnvPrefix, err)
+		}
+		c.Thresholds.Days = days
+	}
+	if v, ok := lookup(EnvPrefix + "FORMAT"); ok {
+		c.Output.Format = v
+	}
+	if v, ok := lookup(EnvPrefix + "OUTPUT"); ok {
+		c.Output.File = v
+	}
+	if v, ok := lookup(EnvPrefix + "NAMESPACE"); ok {
+		c.Namespaces.Include = splitList(v)
+	}
+	if v, ok := lookup(EnvPrefix + "INCLUDE_NAMESPACES"); ok {
+		c.Namespaces.Include = splitList(v)
+	}
+	if v, ok := lookup(EnvPrefix + "EXCLUDE_NAMESPACES"); ok {
+		c.Namespaces.Exclude = splitList(v)
+	}
+	if v, ok := lookup(EnvPrefix + "NAMESPACE_SELECTOR"); ok {
+		c.Namespaces.Selector = v
+	}
+	if v, ok := lookup(EnvPrefix + "SELECTOR"); ok {
+		c.Selector = v
+	}
+	if v, ok := lookup(EnvPrefix + "PAGE_SIZE"); ok {
+		size, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%sPAGE_SIZE: %w", EnvPrefix, err)
+		}
+		c.Scan.PageSize = size
+	}
+	if v, ok := lookup(EnvPrefix + "MARK"); ok {
+		mark, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%sMARK: %w", EnvPrefix, err)
+		}
+		c.Scan.Mark = mark
+	}
+	if v, ok := lookup(EnvPrefix + "BACKUP_DIR"); ok {
+		c.Backup.Dir = v
+	}
+	if v, ok := lookup(EnvPrefix + "BACKUP_NAMESPACE"); ok {
+		c.Backup.Namespace = v
+	}
+	if v, ok := lookup(EnvPrefix + "JOURNAL_PATH"); ok {
+		c.Journal.Path = v
+	}
+	if v, ok := lookup(EnvPrefix + "JOURNAL_NAMESPACE"); ok {
+		c.Journal.Namespace = v
+	}
+	if v, ok := lookup(EnvPrefix + "GRACE_DAYS"); ok {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sGRACE_DAYS: %w", EnvPrefix, err)
+		}
+		c.Quarantine.GraceDays = days
+	}
+	if v, ok := lookup(EnvPrefix + "RESCUE_DAYS"); ok {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sRESCUE_DAYS: %w", EnvPrefix, err)
+		}
+		c.Quarantine.RescueDays = days
+	}
+	if v, ok := lookup(EnvPrefix + "POLICY"); ok {
+		c.Policy = v
+	}
+	return nil
+}
+
+// Validate checks the settings for mistakes
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Thresholds.Days < 1 {
+		errs = append(errs, fmt.Errorf("thresholds.days must be at least 1, got %d", c.Thresholds.Days))
+	}
+	if !slices.Contains(formats, c.Output.Format) {
+		errs = append(errs, fmt.Errorf("output.format must be one of %v, got %q", formats, c.Output.Format))
+	}
+	if c.Clusters.AllContexts && len(c.Clusters.Contexts) > 0 {
+		errs = append(errs, fmt.Errorf("clusters: allContexts and contexts are mutually exclusive"))
+	}
+	if c.Clusters.Concurrency < 1 {
+		errs = append(errs, fmt.Errorf("clusters.concurrency must be at least 1, got %d", c.Clusters.Concurrency))
+	}
+	if c.Quarantine.GraceDays < 1 {
+		errs = append(errs, fmt.Errorf("quarantine.graceDays must be at least 1, got %d", c.Quarantine.GraceDays))
+	}
+	if c.Quarantine.RescueDays < 1 {
+		errs = append(errs, fmt.Errorf("quarantine.rescueDays must be at least 1, got %d", c.Quarantine.RescueDays))
+	}
+	if c.Backup.Dir == "" {
+		errs = append(errs, fmt.Errorf("backup.dir must not be empty"))
+	}
+	if c.Journal.Path == "" {
+		errs = append(errs, fmt.Errorf("journal.path must not be empty"))
+	}
+	if c.Scan.PageSize < 1 {
+		errs = append(errs, fmt.Errorf("scan.pageSize must be at least 1, got %d", c.Scan.PageSize))
+	}
+	for _, p := range []struct {
+		name  string
+		price float64
+	}{
+		{"vcpuHour", c.Cost.Prices.VCPUHour},
+		{"memoryGBHour", c.Cost.Prices.MemoryGBHour},
+		{"gpuHour", c.Cost.Prices.GPUHour},
+	} {
+		if p.price < 0 {
+			errs = append(errs, fmt.Errorf("cost.prices.%s must not be negative, got %g", p.name, p.price))
+		}
+	}
+	if c.Cost.DefaultRunMinutes < 1 {
+		errs = append(errs, fmt.Errorf("cost.defaultRunMinutes must be at least 1, got %d", c.Cost.DefaultRunMinutes))
+	}
+	for _, pattern := range append(append([]string{}, c.Namespaces.Include...), c.Namespaces.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("namespaces: invalid pattern %q", pattern))
+		}
+	}
+	if c.Selector != "" {
+		if _, err := labels.Parse(c.Selector); err != nil {
+			errs = append(errs, fmt.Errorf("selector: %w", err))
+		}
+	}
+	if c.Namespaces.Selector != "" {
+		if _, err := labels.Parse(c.Namespaces.Selector); err != nil {
+			errs = append(errs, fmt.Errorf("namespaces.selector: %w", err))
+		}
+	}
+	for name, r := range c.Rules {
+		if r.Weight != nil && *r.Weight < 0 {
+			errs = append(errs, fmt.Errorf("rules.%s.weight must not be negative", name))
+		}
+	}
+	for i, m := range c.Owners.Mappings {
+		if m.Namespace == "" || m.Owner == "" {
+			errs = append(errs, fmt.Errorf("owners.mappings[%d] needs a namespace and an owner", i))
+		} else if _, err := path.Match(m.Namespace, ""); err != nil {
+			errs = append(errs, fmt.Errorf("owners.mappings[%d].namespace: invalid pattern %q", i, m.Namespace))
+		}
+	}
+	for i, n := range c.Notifications {
+		if !slices.Contains(notificationTypes, n.Type) {
+			errs = append(errs, fmt.Errorf("notifications[%d].type must be one of %v, got %q", i, notificationTypes, n.Type))
+		}
+		if n.MinConfidence < 0 || n.MinConfidence > 99 {
+			errs = append(errs, fmt.Errorf("notifications[%d].minConfidence must be between 0 and 99", i))
+		}
+		if n.URL == "" {
+			errs = append(errs, fmt.Errorf("notifications[%d].url is required", i))
+		}
+		if n.Type == "email" && (n.From == "" || len(n.To) == 0) {
+			errs = append(errs, fmt.Errorf("notifications[%d]: email needs from and to", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// OwnerResolver returns the owner resolver the config describes, loading
+// the mapping file if there is one
+func (c *Config) OwnerResolver() (*owner.Resolver, error) {
+	mappings := c.Owners.Mappings
+	if c.Owners.MappingFile != "" {
+		fromFile, err := owner.LoadMappings(c.Owners.MappingFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load owner mappings: %w", err)
+		}
+		mappings = append(slices.Clone(mappings), fromFile...)
+	}
+	return owner.NewResolver(c.Owners.Keys, mappings), nil
+}
+
+// CostEstimator returns the cost estimator the config describes
+func (c *Config) CostEstimator() *cost.Estimator {
+	return cost.NewEstimator(c.Cost.Prices, time.Duration(c.Cost.DefaultRunMinutes)*time.Minute)
+}
+
+// ClientOptions returns the Kubernetes client settings the config describes
+// for one kubeconfig context ("" for the current one)
+func (c *Config) ClientOptions(context string) k8s.Options {
+	return k8s.Options{
+		Kubeconfig: c.Clusters.Kubeconfig,
+		Context:    context,
+		PageSize:   c.Scan.PageSize,
+		FullJobs:   c.Scan.FullJobs,
+	}
+}
+
+// Contexts returns the kubeconfig contexts to scan. A single "" stands for
+// the current context.
+func (c *Config) Contexts() ([]string, error) {
+	if c.Clusters.AllContexts {
+		contexts, err := k8s.Contexts(c.Clusters.Kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("no contexts found in kubeconfig")
+		}
+		return contexts, nil
+	}
+	if len(c.Clusters.Contexts) > 0 {
+		return c.Clusters.Contexts, nil
+	}
+	return []string{""}, nil
+}
+
+// Filter returns the CronJob selection the config describes
+func (c *Config) Filter() k8s.Filter {
+	return k8s.Filter{
+		IncludeNamespaces: c.Namespaces.Include,
+		ExcludeNamespaces: c.Namespaces.Exclude,
+		NamespaceSelector: c.Namespaces.Selector,
+		LabelSelector:     c.Selector,
+	}
+}
+
+// Redacted returns a copy of the config that is safe to print: webhook
+// secrets, URL passwords and query parameters, and Slack webhook tokens
+// are replaced
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Notifications = make([]Notification, len(c.Notifications))
+	for i, n := range c.Notifications {
+		if n.Secret != "" {
+			n.Secret = redacted
+		}
+		n.URL = redactURL(n.URL)
+		out.Notifications[i] = n
+	}
+	return &out
+}
+
+// redacted replaces secrets in printed configs
+const redacted = "REDACTED"
+
+// redactURL hides the credentials a URL may carry
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+	if u.RawQuery != "" {
+		q := u.Query()
+		for k := range q {
+			q.Set(k, redacted)
+		}
+		u.RawQuery = q.Encode()
+	}
+	// Slack incoming webhooks carry their token in the path
+	if strings.HasPrefix(u.Path, "/services/") {
+		u.Path, u.RawPath = "/services/"+redacted, ""
+	}
+	return u.String()
+}
+
+// dataDir is ~/.zombie-hunter, where backups and the journal are kept by
+// default, or a relative directory when there is no home directory
+func dataDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "zombie-hunter-data"
+	}
+	return filepath.Join(home, ".zombie-hunter")
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
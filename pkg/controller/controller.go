@@ -0,0 +1,494 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	"github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned"
+	zhinformers "github.com/rrdesai64/zombie-hunter/pkg/client/informers/externalversions"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// DefaultResyncInterval is how often every CronJob is re-evaluated even
+// when nothing changed, since CronJobs turn into zombies by time passing
+const DefaultResyncInterval = time.Hour
+
+// jobOwnerIndex indexes Jobs by the UID of the CronJob controlling them
+const jobOwnerIndex = "cronjob-uid"
+
+// Options configure the controller
+type Options struct {
+	// Cluster names the cluster in results and journal entries
+	Cluster string
+	// Filter limits which namespaces and CronJobs are evaluated
+	Filter k8s.Filter
+	// ThresholdDays and GraceDays are used when a policy leaves them unset
+	ThresholdDays int
+	GraceDays     int
+	// RescueDays is how long a CronJob unsuspended during quarantine is
+	// left alone
+	RescueDays int
+	// Rules are extra detection rules, such as CEL policy rules, and
+	// RuleSettings configure all rules
+	Rules        []detector.Rule
+	RuleSettings map[string]detector.RuleSettings
+	// ResyncInterval is how often every CronJob is re-evaluated
+	ResyncInterval time.Duration
+	// Workers is the number of CronJobs evaluated in parallel
+	Workers int
+	// Backups receives CronJobs before the controller deletes them
+	Backups actions.Store
+	// Journal records every change; nil disables journaling
+	Journal actions.Journal
+	// Actor is who changes are attributed to
+	Actor string
+	// ReportInterval is how often changed results are written to
+	// ZombieReports
+	ReportInterval time.Duration
+	// Owners resolves who owns each CronJob; nil leaves only the
+	// zombie-hunter.io/owner annotation
+	Owners *owner.Resolver
+}
+
+// Controller continuously evaluates CronJobs against ZombiePolicies and
+// applies the action of the matching policy
+type Controller struct {
+	opts      Options
+	runner    *actions.Runner
+	factory   informers.SharedInformerFactory
+	zh        versioned.Interface
+	zhFactory zhinformers.SharedInformerFactory
+	results   *results
+
+	cronJobs        cache.SharedIndexInformer
+	jobs            cache.SharedIndexInformer
+	namespaces      cache.SharedIndexInformer
+	policies        cache.SharedIndexInformer
+	clusterPolicies cache.SharedIndexInformer
+
+	queue  workqueue.TypedRateLimitingInterface[string]
+	synced atomic.Bool
+}
+
+// New creates a controller. Nothing is watched until Run is called.
+func New(kube kubernetes.Interface, zh versioned.Interface, opts Options) *Controller {
+	if opts.ResyncInterval <= 0 {
+		opts.ResyncInterval = DefaultResyncInterval
+	}
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.ReportInterval <= 0 {
+		opts.ReportInterval = DefaultReportInterval
+	}
+
+	runner := actions.NewRunner(kube, opts.Cluster, false)
+	if opts.Journal != nil {
+		runner.WithJournal(opts.Journal, opts.Actor)
+	}
+
+	factory := informers.NewSharedInformerFactory(kube, 0)
+	zhFactory := zhinformers.NewSharedInformerFactory(zh, 0)
+	c := &Controller{
+		opts:            opts,
+		runner:          runner,
+		factory:         factory,
+		zh:              zh,
+		zhFactory:       zhFactory,
+		results:         newResults(),
+		cronJobs:        factory.Batch().V1().CronJobs().Informer(),
+		jobs:            factory.Batch().V1().Jobs().Informer(),
+		namespaces:      factory.Core().V1().Namespaces().Informer(),
+		policies:        zhFactory.ZombieHunter().V1alpha1().ZombiePolicies().Informer(),
+		clusterPolicies: zhFactory.ZombieHunter().V1alpha1().ClusterZombiePolicies().Informer(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "zombie-hunter"},
+		),
+	}
+
+	// Jobs are trimmed like in one-shot scans, so a cluster with a long Job
+	// history doesn't keep every pod template in memory
+	c.jobs.SetTransform(func(obj any) (any, error) {
+		if job, ok := obj.(*batchv1.Job); ok {
+			trimmed := k8s.TrimJob(job)
+			return &trimmed, nil
+		}
+		return obj, nil
+	})
+	c.jobs.AddIndexers(cache.Indexers{jobOwnerIndex: func(obj any) ([]string, error) {
+		if uid := k8s.CronJobOwner(obj.(*batchv1.Job)); uid != "" {
+			return []string{string(uid)}, nil
+		}
+		return nil, nil
+	}})
+
+	c.cronJobs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(_, obj any) { c.enqueue(obj) },
+		DeleteFunc: c.enqueue,
+	})
+	c.jobs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueOwner,
+		UpdateFunc: func(_, obj any) { c.enqueueOwner(obj) },
+		DeleteFunc: c.enqueueOwner,
+	})
+	c.namespaces.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj any) { c.enqueueNamespace(obj) },
+	})
+	policyChanged := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { c.enqueueAll() },
+		UpdateFunc: func(any, any) { c.enqueueAll() },
+		DeleteFunc: func(any) { c.enqueueAll() },
+	}
+	c.policies.AddEventHandler(policyChanged)
+	c.clusterPolicies.AddEventHandler(policyChanged)
+
+	return c
+}
+
+// Run watches CronJobs, Jobs, Namespaces and policies and evaluates
+// CronJobs until ctx is cancelled
+func (c *Controller) Run(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting zombie-hunter controller", "cluster", c.opts.Cluster, "workers", c.opts.Workers, "resync", c.opts.ResyncInterval)
+
+	c.factory.Start(ctx.Done())
+	c.zhFactory.Start(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.cronJobs.HasSynced, c.jobs.HasSynced, c.namespaces.HasSynced,
+		c.policies.HasSynced, c.clusterPolicies.HasSynced) {
+		return fmt.Errorf("failed to sync caches")
+	}
+	c.synced.Store(true)
+	klog.InfoS("Caches synced")
+
+	for i := 0; i < c.opts.Workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+	go wait.UntilWithContext(ctx, func(context.Context) { c.enqueueAll() }, c.opts.ResyncInterval)
+	// The first reports are written one interval in, once the initial
+	// evaluation has had time to finish
+	go func() {
+		ticker := time.NewTicker(c.opts.ReportInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.writeReports(ctx)
+			}
+		}
+	}()
+
+	<-ctx.Done()
+	klog.InfoS("Stopping zombie-hunter controller")
+	c.factory.Shutdown()
+	return nil
+}
+
+// Ready reports whether the caches have synced and CronJobs are evaluated
+func (c *Controller) Ready() bool {
+	return c.synced.Load()
+}
+
+func (c *Controller) worker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.reconcile(ctx, key); err != nil {
+		klog.ErrorS(err, "Failed to evaluate CronJob, will retry", "cronjob", key)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// reconcile evaluates one CronJob and applies its policy's action
+func (c *Controller) reconcile(ctx context.Context, key string) error {
+	obj, exists, err := c.cronJobs.GetIndexer().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		namespace, name, _ := cache.SplitMetaNamespaceKey(key)
+		c.results.remove(namespace, name)
+		return nil
+	}
+	cronJob := obj.(*batchv1.CronJob)
+
+	namespaceLabels := c.namespaceLabels(cronJob.Namespace)
+	if !c.inScope(cronJob, namespaceLabels) {
+		c.results.remove(cronJob.Namespace, cronJob.Name)
+		return nil
+	}
+
+	policy := SelectPolicy(cronJob, namespaceLabels, c.namespacedPolicies(cronJob.Namespace), c.allClusterPolicies())
+	d, err := c.detectorFor(policy.Spec)
+	if err != nil {
+		return fmt.Errorf("%s: %w", policy.Source, err)
+	}
+
+	zombie := d.Analyze(cronJob, c.jobsFor(cronJob))
+	zombie.Cluster = c.opts.Cluster
+	zombie.Provenance = k8s.ProvenanceOf(cronJob, actions.FieldManager)
+	if c.opts.Owners != nil {
+		zombie.Owner, zombie.OwnerSource = c.opts.Owners.Resolve(cronJob, namespaceLabels)
+	}
+	c.results.set(zombie, policy, time.Now())
+	return c.act(ctx, cronJob, zombie, policy)
+}
+
+// act applies a policy's action to an evaluated CronJob
+func (c *Controller) act(ctx context.Context, cronJob *batchv1.CronJob, zombie detector.Zombie, policy Policy) error {
+	key := cronJob.Namespace + "/" + cronJob.Name
+	now := time.Now()
+
+	switch policy.Spec.Action {
+	case v1alpha1.ActionReport, "":
+		if zombie.IsZombie {
+			klog.InfoS("Zombie CronJob", "cronjob", key, "confidence", zombie.Confidence,
+				"daysSinceSuccess", zombie.DaysSinceSuccess, "policy", policy.Source)
+		}
+		return nil
+
+	case v1alpha1.ActionMark:
+		switch {
+		case zombie.IsZombie && actions.NeedsMark(cronJob, zombie):
+			_, err := c.runner.Mark(ctx, cronJob, zombie, now)
+			return err
+		case !zombie.IsZombie && actions.Marked(cronJob):
+			_, err := c.runner.Unmark(ctx, cronJob)
+			return err
+		}
+		return nil
+
+	case v1alpha1.ActionQuarantine:
+		switch actions.QuarantineState(cronJob, now) {
+		case actions.StateNotQuarantined:
+			if !c.selected(zombie, policy) {
+				return nil
+			}
+			graceDays := policy.Spec.GraceDays
+			if graceDays == 0 {
+				graceDays = c.opts.GraceDays
+			}
+			reason := fmt.Sprintf("zombie with %d%% confidence under %s", zombie.Confidence, policy.Source)
+			if _, err := c.runner.Quarantine(ctx, cronJob, c.opts.Actor, reason, now.AddDate(0, 0, graceDays), now); err != nil {
+				return err
+			}
+			klog.InfoS("Quarantined zombie CronJob", "cronjob", key, "graceDays", graceDays, "policy", policy.Source)
+		case actions.StateRescued:
+			if _, err := c.runner.Rescue(ctx, cronJob, now.AddDate(0, 0, c.opts.RescueDays)); err != nil {
+				return err
+			}
+			klog.InfoS("CronJob unsuspended during quarantine, rescued", "cronjob", key)
+		case actions.StateExpired:
+			return c.delete(ctx, cronJob, policy)
+		case actions.StateInvalid:
+			klog.InfoS("Quarantined CronJob has no valid deadline, leaving it alone", "cronjob", key,
+				"annotation", detector.AnnotationDeleteAfter)
+		}
+		return nil
+
+	case v1alpha1.ActionDelete:
+		if !c.selected(zombie, policy) {
+			return nil
+		}
+		return c.delete(ctx, cronJob, policy)
+	}
+
+	klog.InfoS("Ignoring unknown policy action", "action", policy.Spec.Action, "policy", policy.Source)
+	return nil
+}
+
+// selected reports whether a destructive action applies to a result.
+// CronJobs deployed by Argo CD, Flux or Helm are only reported, since their
+// source would undo the action.
+func (c *Controller) selected(zombie detector.Zombie, policy Policy) bool {
+	if !zombie.IsZombie || zombie.Confidence < policy.Spec.MinConfidence {
+		return false
+	}
+	if src := zombie.GitOps; src != nil {
+		klog.InfoS("Not acting on GitOps-managed zombie CronJob", "cronjob", zombie.Namespace+"/"+zombie.Name,
+			"source", src.String(), "suggestion", src.Action, "policy", policy.Source)
+		return false
+	}
+	return true
+}
+
+// delete backs up a CronJob and deletes it
+func (c *Controller) delete(ctx context.Context, cronJob *batchv1.CronJob, policy Policy) error {
+	key := cronJob.Namespace + "/" + cronJob.Name
+	if c.opts.Backups == nil {
+		return fmt.Errorf("%s asks to delete %s, but no backup store is configured", policy.Source, key)
+	}
+
+	backup := actions.NewBackup(c.opts.Cluster, []batchv1.CronJob{*cronJob}, time.Now())
+	if err := c.opts.Backups.Save(ctx, backup); err != nil {
+		return fmt.Errorf("failed to save backup, not deleting: %w", err)
+	}
+	if err := c.runner.Delete(ctx, cronJob); err != nil {
+		return err
+	}
+	klog.InfoS("Deleted zombie CronJob", "cronjob", key, "backup", c.opts.Backups.Location(backup.ID), "policy", policy.Source)
+	return nil
+}
+
+// detectorFor builds a detector with the policy's threshold and rules
+func (c *Controller) detectorFor(spec v1alpha1.ZombiePolicySpec) (*detector.Detector, error) {
+	rules := detector.DefaultRegistry()
+	for _, rule := range c.opts.Rules {
+		if err := rules.Register(rule); err != nil {
+			return nil, err
+		}
+	}
+	if err := rules.Configure(c.opts.RuleSettings); err != nil {
+		return nil, err
+	}
+
+	disabled := map[string]detector.RuleSettings{}
+	off := false
+	for _, name := range spec.DisabledRules {
+		disabled[name] = detector.RuleSettings{Enabled: &off}
+	}
+	if err := rules.Configure(disabled); err != nil {
+		return nil, err
+	}
+
+	thresholdDays := spec.ThresholdDays
+	if thresholdDays == 0 {
+		thresholdDays = c.opts.ThresholdDays
+	}
+	return detector.NewDetector(rules, thresholdDays), nil
+}
+
+// inScope applies the namespace and label filters
+func (c *Controller) inScope(cronJob *batchv1.CronJob, namespaceLabels map[string]string) bool {
+	f := c.opts.Filter
+	if !f.MatchesNamespace(cronJob.Namespace) {
+		return false
+	}
+	for _, s := range []struct {
+		selector string
+		set      map[string]string
+	}{
+		{f.NamespaceSelector, namespaceLabels},
+		{f.LabelSelector, cronJob.Labels},
+	} {
+		if s.selector == "" {
+			continue
+		}
+		selector, err := labels.Parse(s.selector)
+		if err != nil || !selector.Matches(labels.Set(s.set)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Controller) jobsFor(cronJob *batchv1.CronJob) []batchv1.Job {
+	objs, _ := c.jobs.GetIndexer().ByIndex(jobOwnerIndex, string(cronJob.UID))
+	jobs := make([]batchv1.Job, 0, len(objs))
+	for _, obj := range objs {
+		jobs = append(jobs, *obj.(*batchv1.Job))
+	}
+	return jobs
+}
+
+func (c *Controller) namespaceLabels(namespace string) map[string]string {
+	obj, exists, _ := c.namespaces.GetIndexer().GetByKey(namespace)
+	if !exists {
+		return nil
+	}
+	return obj.(*corev1.Namespace).Labels
+}
+
+func (c *Controller) namespacedPolicies(namespace string) []*v1alpha1.ZombiePolicy {
+	objs, _ := c.policies.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	policies := make([]*v1alpha1.ZombiePolicy, 0, len(objs))
+	for _, obj := range objs {
+		policies = append(policies, obj.(*v1alpha1.ZombiePolicy))
+	}
+	return policies
+}
+
+func (c *Controller) allClusterPolicies() []*v1alpha1.ClusterZombiePolicy {
+	objs := c.clusterPolicies.GetIndexer().List()
+	policies := make([]*v1alpha1.ClusterZombiePolicy, 0, len(objs))
+	for _, obj := range objs {
+		policies = append(policies, obj.(*v1alpha1.ClusterZombiePolicy))
+	}
+	return policies
+}
+
+func (c *Controller) enqueue(obj any) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueueOwner enqueues the CronJob controlling a Job
+func (c *Controller) enqueueOwner(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return
+	}
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+		c.queue.Add(job.Namespace + "/" + owner.Name)
+	}
+}
+
+// enqueueNamespace re-evaluates a namespace's CronJobs when its labels, and
+// so the policies selecting it, may have changed
+func (c *Controller) enqueueNamespace(obj any) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	objs, _ := c.cronJobs.GetIndexer().ByIndex(cache.NamespaceIndex, ns.Name)
+	for _, obj := range objs {
+		c.enqueue(obj)
+	}
+}
+
+func (c *Controller) enqueueAll() {
+	for _, key := range c.cronJobs.GetIndexer().ListKeys() {
+		c.queue.Add(key)
+	}
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	zhfake "github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned/fake"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		gitOps bool
+		check  func(t *testing.T, clientset *fake.Clientset)
+	}{
+		{
+			name:   "Report leaves the CronJob alone",
+			action: v1alpha1.ActionReport,
+			check: func(t *testing.T, clientset *fake.Clientset) {
+				cj := getCronJob(t, clientset)
+				if actions.Marked(cj) || *cj.Spec.Suspend {
+					t.Errorf("CronJob changed under Report: %+v", cj.ObjectMeta)
+				}
+			},
+		},
+		{
+			name:   "Mark labels the zombie",
+			action: v1alpha1.ActionMark,
+			check: func(t *testing.T, clientset *fake.Clientset) {
+				if cj := getCronJob(t, clientset); cj.Labels[actions.LabelStatus] != actions.StatusZombie {
+					t.Errorf("labels = %v; want %s=%s", cj.Labels, actions.LabelStatus, actions.StatusZombie)
+				}
+			},
+		},
+		{
+			name:   "Quarantine suspends the zombie",
+			action: v1alpha1.ActionQuarantine,
+			check: func(t *testing.T, clientset *fake.Clientset) {
+				cj := getCronJob(t, clientset)
+				if got := actions.QuarantineState(cj, time.Now()); got != actions.StatePending {
+					t.Errorf("quarantine state = %q; want %q", got, actions.StatePending)
+				}
+			},
+		},
+		{
+			name:   "Delete backs up and deletes the zombie",
+			action: v1alpha1.ActionDelete,
+			check: func(t *testing.T, clientset *fake.Clientset) {
+				if _, err := clientset.BatchV1().CronJobs("default").Get(context.Background(), "billing", metav1.GetOptions{}); err == nil {
+					t.Errorf("CronJob still exists")
+				}
+				backups, _ := clientset.CoreV1().ConfigMaps("zombie-hunter").List(context.Background(), metav1.ListOptions{
+					LabelSelector: actions.LabelBackup + "=true",
+				})
+				if len(backups.Items) != 1 {
+					t.Errorf("got %d backups; want 1", len(backups.Items))
+				}
+			},
+		},
+		{
+			name:   "Delete leaves a GitOps-managed zombie alone",
+			action: v1alpha1.ActionDelete,
+			gitOps: true,
+			check: func(t *testing.T, clientset *fake.Clientset) {
+				getCronJob(t, clientset)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cj, job, ns := staleCronJob()
+			if tt.gitOps {
+				cj.Annotations = map[string]string{k8s.AnnotationArgoTrackingID: "billing:batch/CronJob:default/billing"}
+			}
+			clientset := fake.NewClientset(cj, job, ns)
+
+			c := New(clientset, zhfake.NewSimpleClientset(), Options{
+				Cluster:       "test",
+				ThresholdDays: 30,
+				GraceDays:     14,
+				Backups:       actions.NewConfigMapStore(clientset, "zombie-hunter"),
+				Actor:         "test",
+			})
+			c.cronJobs.GetIndexer().Add(cj)
+			c.jobs.GetIndexer().Add(job)
+			c.namespaces.GetIndexer().Add(ns)
+			c.clusterPolicies.GetIndexer().Add(&v1alpha1.ClusterZombiePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "all"},
+				Spec:       v1alpha1.ZombiePolicySpec{Action: tt.action, MinConfidence: 50},
+			})
+
+			if err := c.reconcile(ctx, "default/billing"); err != nil {
+				t.Fatalf("reconcile() failed: %v", err)
+			}
+			tt.check(t, clientset)
+		})
+	}
+}
+
+func TestReconcileSkipsOutOfScope(t *testing.T) {
+	cj, job, ns := staleCronJob()
+	clientset := fake.NewClientset(cj, job, ns)
+
+	c := New(clientset, zhfake.NewSimpleClientset(), Options{Cluster: "test", ThresholdDays: 30})
+	c.opts.Filter.ExcludeNamespaces = []string{"def*"}
+	c.cronJobs.GetIndexer().Add(cj)
+	c.namespaces.GetIndexer().Add(ns)
+	c.clusterPolicies.GetIndexer().Add(&v1alpha1.ClusterZombiePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "all"},
+		Spec:       v1alpha1.ZombiePolicySpec{Action: v1alpha1.ActionMark},
+	})
+
+	if err := c.reconcile(context.Background(), "default/billing"); err != nil {
+		t.Fatalf("reconcile() failed: %v", err)
+	}
+	if actions.Marked(getCronJob(t, clientset)) {
+		t.Errorf("CronJob in an excluded namespace was marked")
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	ready := false
+	server := httptest.NewServer(HealthHandler(func() bool { return ready }))
+	defer server.Close()
+
+	expect := func(path string, status int) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("GET %s = %d; want %d", path, resp.StatusCode, status)
+		}
+	}
+
+	expect("/healthz", http.StatusOK)
+	expect("/readyz", http.StatusServiceUnavailable)
+	ready = true
+	expect("/readyz", http.StatusOK)
+}
+
+// staleCronJob returns a daily CronJob whose last success was 60 days ago,
+// its Job and its namespace
+func staleCronJob() (*batchv1.CronJob, *batchv1.Job, *corev1.Namespace) {
+	now := time.Now()
+	suspend := false
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "billing",
+			UID:               "uid-billing",
+			ResourceVersion:   "1",
+			CreationTimestamp: metav1.NewTime(now.AddDate(-1, 0, 0)),
+		},
+		Spec: batchv1.CronJobSpec{Schedule: "@daily", Suspend: &suspend},
+	}
+
+	controller := true
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "billing-1",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1", Kind: "CronJob", Name: "billing", UID: cj.UID, Controller: &controller,
+			}},
+		},
+		Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.AddDate(0, 0, -60)),
+		}}},
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	return cj, job, ns
+}
+
+func getCronJob(t *testing.T, clientset *fake.Clientset) *batchv1.CronJob {
+	t.Helper()
+	cj, err := clientset.BatchV1().CronJobs("default").Get(context.Background(), "billing", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	return cj
+}
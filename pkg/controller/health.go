@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"net/http"
+)
+
+// HealthHandler serves /healthz, which succeeds while the process runs, and
+// /readyz, which succeeds once ready returns true
+func HealthHandler(ready func() bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
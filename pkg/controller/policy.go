@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"sort"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// DefaultPolicy names the policy used for CronJobs no ZombiePolicy selects
+const DefaultPolicy = "default"
+
+// Policy is the policy that applies to one CronJob
+type Policy struct {
+	// Source names the policy, e.g. "ZombiePolicy team-a/strict"
+	Source string
+	Spec   v1alpha1.ZombiePolicySpec
+}
+
+// SelectPolicy picks the policy for a CronJob: the first ZombiePolicy in its
+// namespace whose selector matches, else the first matching
+// ClusterZombiePolicy, else a Report-only default. Policies are tried in
+// name order so the choice is stable.
+func SelectPolicy(cronJob *batchv1.CronJob, namespaceLabels map[string]string, policies []*v1alpha1.ZombiePolicy, clusterPolicies []*v1alpha1.ClusterZombiePolicy) Policy {
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	for _, p := range policies {
+		if p.Namespace == cronJob.Namespace && selects(p.Spec.Selector, cronJob.Labels) {
+			return Policy{Source: "ZombiePolicy " + p.Namespace + "/" + p.Name, Spec: p.Spec}
+		}
+	}
+
+	sort.Slice(clusterPolicies, func(i, j int) bool { return clusterPolicies[i].Name < clusterPolicies[j].Name })
+	for _, p := range clusterPolicies {
+		if selects(p.Spec.NamespaceSelector, namespaceLabels) && selects(p.Spec.Selector, cronJob.Labels) {
+			return Policy{Source: "ClusterZombiePolicy " + p.Name, Spec: p.Spec}
+		}
+	}
+
+	return Policy{Source: DefaultPolicy, Spec: v1alpha1.ZombiePolicySpec{Action: v1alpha1.ActionReport}}
+}
+
+// selects reports whether a label selector matches; nil matches everything
+// and an invalid selector matches nothing
+func selects(selector *metav1.LabelSelector, set map[string]string) bool {
+	if selector == nil {
+		return true
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		klog.ErrorS(err, "Ignoring policy with an invalid selector")
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectPolicy(t *testing.T) {
+	teamSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+
+	namespaced := []*v1alpha1.ZombiePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "payments"},
+			Spec:       v1alpha1.ZombiePolicySpec{Selector: teamSelector, Action: v1alpha1.ActionDelete},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "all"},
+			Spec:       v1alpha1.ZombiePolicySpec{Action: v1alpha1.ActionMark},
+		},
+	}
+	cluster := []*v1alpha1.ClusterZombiePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-prod"},
+			Spec: v1alpha1.ZombiePolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Action:            v1alpha1.ActionQuarantine,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-broken"},
+			Spec: v1alpha1.ZombiePolicySpec{
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "x", Operator: "Bogus"}}},
+				Action:   v1alpha1.ActionDelete,
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		labels          map[string]string
+		namespaceLabels map[string]string
+		expected        string
+	}{
+		{
+			name:     "Namespaced policy wins",
+			labels:   map[string]string{"team": "payments"},
+			expected: "ZombiePolicy default/payments",
+		},
+		{
+			name:            "Falls back to a cluster policy",
+			labels:          map[string]string{"team": "search"},
+			namespaceLabels: map[string]string{"env": "prod"},
+			expected:        "ClusterZombiePolicy b-prod",
+		},
+		{
+			name:            "Default when nothing matches",
+			labels:          map[string]string{"team": "search"},
+			namespaceLabels: map[string]string{"env": "dev"},
+			expected:        DefaultPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job", Labels: tt.labels}}
+
+			got := SelectPolicy(cronJob, tt.namespaceLabels, namespaced, cluster)
+			if got.Source != tt.expected {
+				t.Errorf("SelectPolicy() = %q; want %q", got.Source, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	"github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// DefaultReportInterval is how often changed results are written to
+// ZombieReports, so a resync doesn't turn into one write per CronJob
+const DefaultReportInterval = time.Minute
+
+// result is the latest evaluation of one CronJob
+type result struct {
+	zombie v1alpha1.ZombieResult
+	// listed is set for zombies and acknowledged CronJobs, the ones a
+	// report names
+	listed bool
+}
+
+// results collects evaluations per namespace until they are written
+type results struct {
+	mu         sync.Mutex
+	namespaces map[string]map[string]result
+	scanned    map[string]time.Time
+	dirty      map[string]bool
+	// clusterDirty is set when the ClusterZombieReport failed to be
+	// written, so it is retried even if no namespace changes
+	clusterDirty bool
+}
+
+func newResults() *results {
+	return &results{
+		namespaces: map[string]map[string]result{},
+		scanned:    map[string]time.Time{},
+		dirty:      map[string]bool{},
+	}
+}
+
+// set records the evaluation of a CronJob
+func (r *results) set(zombie detector.Zombie, policy Policy, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.namespaces[zombie.Namespace] == nil {
+		r.namespaces[zombie.Namespace] = map[string]result{}
+	}
+	r.namespaces[zombie.Namespace][zombie.Name] = result{
+		zombie: resultFor(zombie, policy),
+		listed: zombie.IsZombie || zombie.Acknowledged,
+	}
+	r.scanned[zombie.Namespace] = now
+	r.dirty[zombie.Namespace] = true
+}
+
+// remove forgets a CronJob that was deleted or left the controller's scope
+func (r *results) remove(namespace, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.namespaces[namespace][name]; !ok {
+		return
+	}
+	delete(r.namespaces[namespace], name)
+	r.dirty[namespace] = true
+}
+
+// changed returns the status of every namespace changed since the last call,
+// and the cluster status if any did or its last write failed
+func (r *results) changed() (map[string]v1alpha1.ZombieReportStatus, *v1alpha1.ClusterZombieReportStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.dirty) == 0 && !r.clusterDirty {
+		return nil, nil
+	}
+	statuses := map[string]v1alpha1.ZombieReportStatus{}
+	for namespace := range r.dirty {
+		statuses[namespace] = buildReportStatus(r.namespaces[namespace], r.scanned[namespace])
+	}
+	r.dirty = map[string]bool{}
+	r.clusterDirty = false
+
+	cluster := buildClusterReportStatus(r.namespaces, r.scanned)
+	return statuses, &cluster
+}
+
+// retry marks namespaces whose report failed to be written
+func (r *results) retry(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirty[namespace] = true
+}
+
+// retryCluster marks the ClusterZombieReport as failed to be written
+func (r *results) retryCluster() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clusterDirty = true
+}
+
+// resultFor converts a detector result into its report form
+func resultFor(zombie detector.Zombie, policy Policy) v1alpha1.ZombieResult {
+	res := v1alpha1.ZombieResult{
+		Name:             zombie.Name,
+		Confidence:       zombie.Confidence,
+		DaysSinceSuccess: zombie.DaysSinceSuccess,
+		ExpectedRuns:     zombie.ExpectedRuns,
+		MissedRuns:       zombie.MissedRuns,
+		Acknowledged:     zombie.Acknowledged,
+		Owner:            zombie.Owner,
+		OwnerSource:      zombie.OwnerSource,
+		ManagedBy:        zombie.ManagedBy,
+		LastModifiedBy:   zombie.LastModifiedBy,
+		Policy:           policy.Source,
+	}
+	if zombie.GitOps != nil {
+		res.GitOpsSource, res.SuggestedAction = zombie.GitOps.String(), zombie.GitOps.Action
+	}
+	if zombie.LastSuccessTime != nil {
+		t := metav1.NewTime(*zombie.LastSuccessTime)
+		res.LastSuccessTime = &t
+	}
+	for _, s := range zombie.Signals {
+		res.Signals = append(res.Signals, v1alpha1.ZombieSignal{
+			Name:     s.Name,
+			Rule:     s.Rule,
+			Weight:   s.Weight,
+			Observed: s.Observed,
+			Message:  s.Message,
+		})
+	}
+	return res
+}
+
+// buildReportStatus lists a namespace's zombies and acknowledged CronJobs,
+// most confident first
+func buildReportStatus(namespace map[string]result, scanned time.Time) v1alpha1.ZombieReportStatus {
+	status := v1alpha1.ZombieReportStatus{LastScanned: metav1.NewTime(scanned)}
+	for _, r := range namespace {
+		count(&status.Summary, r)
+		if r.listed {
+			status.Zombies = append(status.Zombies, r.zombie)
+		}
+	}
+	sort.Slice(status.Zombies, func(i, j int) bool {
+		a, b := status.Zombies[i], status.Zombies[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.Name < b.Name
+	})
+	return status
+}
+
+// buildClusterReportStatus totals every namespace with CronJobs in scope
+func buildClusterReportStatus(namespaces map[string]map[string]result, scanned map[string]time.Time) v1alpha1.ClusterZombieReportStatus {
+	var status v1alpha1.ClusterZombieReportStatus
+	for name, namespace := range namespaces {
+		if len(namespace) == 0 {
+			continue
+		}
+		summary := v1alpha1.NamespaceSummary{Namespace: name}
+		for _, r := range namespace {
+			count(&summary.ReportSummary, r)
+			count(&status.Summary, r)
+		}
+		status.Namespaces = append(status.Namespaces, summary)
+		if scanned[name].After(status.LastScanned.Time) {
+			status.LastScanned = metav1.NewTime(scanned[name])
+		}
+	}
+	sort.Slice(status.Namespaces, func(i, j int) bool {
+		return status.Namespaces[i].Namespace < status.Namespaces[j].Namespace
+	})
+	return status
+}
+
+// count adds one evaluated CronJob to a summary
+func count(s *v1alpha1.ReportSummary, r result) {
+	s.CronJobs++
+	switch {
+	case r.zombie.Acknowledged:
+		s.Acknowledged++
+	case r.listed:
+		s.Zombies++
+	}
+}
+
+// writeReports writes the ZombieReports of namespaces whose results changed
+// and the ClusterZombieReport. Failed writes are retried next time.
+func (c *Controller) writeReports(ctx context.Context) {
+	statuses, cluster := c.results.changed()
+	for namespace, status := range statuses {
+		if err := writeReport(ctx, c.zh, namespace, status); err != nil {
+			klog.ErrorS(err, "Failed to write ZombieReport, will retry", "namespace", namespace)
+			c.results.retry(namespace)
+		}
+	}
+	if cluster == nil {
+		return
+	}
+	if err := writeClusterReport(ctx, c.zh, *cluster); err != nil {
+		klog.ErrorS(err, "Failed to write ClusterZombieReport, will retry")
+		c.results.retryCluster()
+	}
+}
+
+// writeReport creates a namespace's ZombieReport if needed and replaces its
+// status
+func writeReport(ctx context.Context, zh versioned.Interface, namespace string, status v1alpha1.ZombieReportStatus) error {
+	reports := zh.ZombieHunterV1alpha1().ZombieReports(namespace)
+	report, err := reports.Get(ctx, v1alpha1.DefaultReportName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		report, err = reports.Create(ctx, &v1alpha1.ZombieReport{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.DefaultReportName, Namespace: namespace, Labels: reportLabels()},
+		}, metav1.CreateOptions{FieldManager: actions.FieldManager})
+	}
+	if err != nil {
+		return err
+	}
+
+	report.Status = status
+	if _, err := reports.UpdateStatus(ctx, report, metav1.UpdateOptions{FieldManager: actions.FieldManager}); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	return nil
+}
+
+// writeClusterReport is writeReport for the ClusterZombieReport
+func writeClusterReport(ctx context.Context, zh versioned.Interface, status v1alpha1.ClusterZombieReportStatus) error {
+	reports := zh.ZombieHunterV1alpha1().ClusterZombieReports()
+	report, err := reports.Get(ctx, v1alpha1.DefaultReportName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		report, err = reports.Create(ctx, &v1alpha1.ClusterZombieReport{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.DefaultReportName, Labels: reportLabels()},
+		}, metav1.CreateOptions{FieldManager: actions.FieldManager})
+	}
+	if err != nil {
+		return err
+	}
+
+	report.Status = status
+	if _, err := reports.UpdateStatus(ctx, report, metav1.UpdateOptions{FieldManager: actions.FieldManager}); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	return nil
+}
+
+func reportLabels() map[string]string {
+	return map[string]string{actions.LabelManagedBy: actions.ManagedBy}
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/actions"
+	"github.com/rrdesai64/zombie-hunter/pkg/apis/zombiehunter/v1alpha1"
+	"github.com/rrdesai64/zombie-hunter/pkg/client/clientset/versioned"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestResults(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := Policy{Source: DefaultPolicy}
+	r := newResults()
+
+	r.set(detector.Zombie{Namespace: "team-a", Name: "healthy"}, policy, now)
+	r.set(detector.Zombie{Namespace: "team-a", Name: "billing", IsZombie: true, Confidence: 60,
+		Signals: []detector.Signal{{Name: "no-success", Weight: 60}}}, policy, now)
+	r.set(detector.Zombie{Namespace: "team-a", Name: "backup", IsZombie: true, Confidence: 90}, policy, now)
+	r.set(detector.Zombie{Namespace: "team-b", Name: "legacy", Acknowledged: true, Confidence: 80}, policy, now.Add(time.Hour))
+
+	statuses, cluster := r.changed()
+	if len(statuses) != 2 || cluster == nil {
+		t.Fatalf("changed() = %d statuses, cluster %v; want 2 and a cluster status", len(statuses), cluster)
+	}
+
+	teamA := statuses["team-a"]
+	if want := (v1alpha1.ReportSummary{CronJobs: 3, Zombies: 2}); teamA.Summary != want {
+		t.Errorf("team-a summary = %+v; want %+v", teamA.Summary, want)
+	}
+	if len(teamA.Zombies) != 2 || teamA.Zombies[0].Name != "backup" || teamA.Zombies[1].Name != "billing" {
+		t.Errorf("team-a zombies = %+v; want backup then billing", teamA.Zombies)
+	}
+	if s := teamA.Zombies[1].Signals; len(s) != 1 || s[0].Name != "no-success" || s[0].Weight != 60 {
+		t.Errorf("billing signals = %+v", s)
+	}
+	if !teamA.LastScanned.Time.Equal(now) {
+		t.Errorf("team-a lastScanned = %v; want %v", teamA.LastScanned, now)
+	}
+
+	if want := (v1alpha1.ReportSummary{CronJobs: 4, Zombies: 2, Acknowledged: 1}); cluster.Summary != want {
+		t.Errorf("cluster summary = %+v; want %+v", cluster.Summary, want)
+	}
+	if len(cluster.Namespaces) != 2 || cluster.Namespaces[0].Namespace != "team-a" {
+		t.Errorf("cluster namespaces = %+v", cluster.Namespaces)
+	}
+	if !cluster.LastScanned.Time.Equal(now.Add(time.Hour)) {
+		t.Errorf("cluster lastScanned = %v; want the latest namespace", cluster.LastScanned)
+	}
+
+	if statuses, _ := r.changed(); statuses != nil {
+		t.Errorf("changed() without changes = %v; want nil", statuses)
+	}
+
+	// A failed cluster report is rewritten though no namespace changed
+	r.retryCluster()
+	if statuses, cluster := r.changed(); len(statuses) != 0 || cluster == nil || cluster.Summary.CronJobs != 4 {
+		t.Errorf("changed() after retryCluster() = %v, %+v; want only the cluster status", statuses, cluster)
+	}
+	if _, cluster := r.changed(); cluster != nil {
+		t.Errorf("changed() after the retry = %+v; want nil", cluster)
+	}
+
+	r.remove("team-b", "legacy")
+	statuses, cluster = r.changed()
+	if len(statuses["team-b"].Zombies) != 0 || len(cluster.Namespaces) != 1 {
+		t.Errorf("after remove: team-b = %+v, cluster namespaces = %+v", statuses["team-b"], cluster.Namespaces)
+	}
+}
+
+// fakeReports serves just enough of the ZombieReport API for writeReport
+type fakeReports struct {
+	mu       sync.Mutex
+	requests []string
+	stored   map[string]json.RawMessage
+}
+
+func (f *fakeReports) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	path := req.URL.Path
+	switch req.Method {
+	case http.MethodGet:
+		body, ok := f.stored[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound,
+			})
+			return
+		}
+		w.Write(body)
+	case http.MethodPost:
+		var body json.RawMessage
+		json.NewDecoder(req.Body).Decode(&body)
+		f.stored[path+"/"+v1alpha1.DefaultReportName] = body
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	case http.MethodPut:
+		var body json.RawMessage
+		json.NewDecoder(req.Body).Decode(&body)
+		f.stored[strings.TrimSuffix(path, "/status")] = body
+		w.Write(body)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	fake := &fakeReports{stored: map[string]json.RawMessage{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	zh, err := versioned.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewForConfig() failed: %v", err)
+	}
+
+	ctx := context.Background()
+	status := v1alpha1.ZombieReportStatus{
+		Summary: v1alpha1.ReportSummary{CronJobs: 1, Zombies: 1},
+		Zombies: []v1alpha1.ZombieResult{{Name: "billing", Confidence: 90}},
+	}
+	for i := 0; i < 2; i++ {
+		if err := writeReport(ctx, zh, "team-a", status); err != nil {
+			t.Fatalf("writeReport() failed: %v", err)
+		}
+	}
+
+	base := "/apis/zombie-hunter.io/v1alpha1/namespaces/team-a/zombiereports"
+	want := []string{
+		"GET " + base + "/zombie-hunter",
+		"POST " + base,
+		"PUT " + base + "/zombie-hunter/status",
+		"GET " + base + "/zombie-hunter",
+		"PUT " + base + "/zombie-hunter/status",
+	}
+	if len(fake.requests) != len(want) {
+		t.Fatalf("requests = %v; want %v", fake.requests, want)
+	}
+	for i := range want {
+		if fake.requests[i] != want[i] {
+			t.Errorf("request %d = %q; want %q", i, fake.requests[i], want[i])
+		}
+	}
+
+	report, err := zh.ZombieHunterV1alpha1().ZombieReports("team-a").Get(ctx, v1alpha1.DefaultReportName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if report.Status.Summary != status.Summary || len(report.Status.Zombies) != 1 {
+		t.Errorf("stored status = %+v; want %+v", report.Status, status)
+	}
+	if report.Labels[actions.LabelManagedBy] != actions.ManagedBy {
+		t.Errorf("labels = %v; want %s=%s like other zombie-hunter objects", report.Labels, actions.LabelManagedBy, actions.ManagedBy)
+	}
+}
@@ -0,0 +1,187 @@
+package cost
+
+import (
+	"math"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Month is the period estimates cover
+const Month = 30 * 24 * time.Hour
+
+// DefaultRunDuration is assumed for CronJobs with no finished Jobs retained
+const DefaultRunDuration = 5 * time.Minute
+
+// How an estimate's run duration was found
+const (
+	BasisObserved  = "observed"
+	BasisAssumed   = "assumed"
+	BasisSuspended = "suspended"
+)
+
+// PriceSheet is what compute costs per hour
+type PriceSheet struct {
+	VCPUHour float64 `json:"vcpuHour"`
+	// MemoryGBHour is per GiB, as cloud providers bill it
+	MemoryGBHour float64 `json:"memoryGBHour"`
+	GPUHour      float64 `json:"gpuHour,omitempty"`
+	Currency     string  `json:"currency"`
+}
+
+// DefaultPrices are roughly on-demand cloud list prices; set your own in
+// the cost section of the config file
+var DefaultPrices = PriceSheet{VCPUHour: 0.0316, MemoryGBHour: 0.0042, GPUHour: 2.50, Currency: "USD"}
+
+// gpuResources are the extended resources counted as GPUs
+var gpuResources = []corev1.ResourceName{"nvidia.com/gpu", "amd.com/gpu"}
+
+// Estimator prices CronJobs
+type Estimator struct {
+	prices     PriceSheet
+	defaultRun time.Duration
+}
+
+// NewEstimator creates an estimator using the price sheet, assuming
+// defaultRun for CronJobs whose run duration can't be observed
+func NewEstimator(prices PriceSheet, defaultRun time.Duration) *Estimator {
+	return &Estimator{prices: prices, defaultRun: defaultRun}
+}
+
+// Currency returns the currency of the estimates
+func (e *Estimator) Currency() string {
+	return e.prices.Currency
+}
+
+// Estimate prices a CronJob's next month of runs: the schedule activations
+// in the coming 30 days, times the average duration of its retained Jobs,
+// times the pod template's resource requests for each pod a run starts.
+// Suspended CronJobs and unparseable schedules cost nothing.
+func (e *Estimator) Estimate(cronJob *batchv1.CronJob, jobs []batchv1.Job, now time.Time) detector.CostEstimate {
+	var est detector.CostEstimate
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		est.CostBasis = BasisSuspended
+		return est
+	}
+	sched, err := detector.ParseSchedule(cronJob)
+	if err != nil {
+		return est
+	}
+
+	est.MonthlyRuns = detector.CountRuns(sched, now, now.Add(Month))
+	est.RunDuration, est.CostBasis = e.runDuration(jobs)
+
+	jobSpec := cronJob.Spec.JobTemplate.Spec
+	cpu, memoryGB, gpu := Requests(&jobSpec.Template.Spec)
+	podHours := float64(est.MonthlyRuns) * est.RunDuration.Hours() * float64(podsPerRun(jobSpec))
+
+	est.MonthlyCPUHours = round(cpu * podHours)
+	est.MonthlyMemoryGBHours = round(memoryGB * podHours)
+	est.MonthlyGPUHours = round(gpu * podHours)
+	est.EstimatedMonthlyCost = round(cpu*podHours*e.prices.VCPUHour +
+		memoryGB*podHours*e.prices.MemoryGBHour +
+		gpu*podHours*e.prices.GPUHour)
+	return est
+}
+
+// runDuration averages how long the finished Jobs ran, from start to
+// completion or failure
+func (e *Estimator) runDuration(jobs []batchv1.Job) (time.Duration, string) {
+	var total time.Duration
+	n := 0
+	for i := range jobs {
+		if d, ok := jobDuration(&jobs[i]); ok {
+			total += d
+			n++
+		}
+	}
+	if n == 0 {
+		return e.defaultRun, BasisAssumed
+	}
+	return total / time.Duration(n), BasisObserved
+}
+
+// jobDuration returns how long a finished Job ran
+func jobDuration(job *batchv1.Job) (time.Duration, bool) {
+	if job.Status.StartTime == nil {
+		return 0, false
+	}
+	end := job.Status.CompletionTime
+	if end == nil {
+		for _, c := range job.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+				end = &c.LastTransitionTime
+				break
+			}
+		}
+	}
+	if end == nil || end.Before(job.Status.StartTime) {
+		return 0, false
+	}
+	return end.Sub(job.Status.StartTime.Time), true
+}
+
+// Requests returns the vCPUs, GiB of memory and GPUs a pod is scheduled
+// with: the larger of its containers' total and its biggest init
+// container, counting limits where no request is set, as the API server
+// defaults them
+func Requests(spec *corev1.PodSpec) (cpu, memoryGB, gpu float64) {
+	cpu = effective(spec, func(c corev1.Container) float64 { return quantity(c, corev1.ResourceCPU) })
+	memoryGB = effective(spec, func(c corev1.Container) float64 { return quantity(c, corev1.ResourceMemory) }) / (1 << 30)
+	gpu = effective(spec, func(c corev1.Container) float64 {
+		total := 0.0
+		for _, name := range gpuResources {
+			total += quantity(c, name)
+		}
+		return total
+	})
+	return cpu, memoryGB, gpu
+}
+
+// effective combines one resource over a pod's containers. Sidecars (init
+// containers that keep running) add to the app containers.
+func effective(spec *corev1.PodSpec, amount func(corev1.Container) float64) float64 {
+	sum, maxInit := 0.0, 0.0
+	for _, c := range spec.Containers {
+		sum += amount(c)
+	}
+	for _, c := range spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sum += amount(c)
+		} else {
+			maxInit = math.Max(maxInit, amount(c))
+		}
+	}
+	return math.Max(sum, maxInit)
+}
+
+// quantity returns a container's request for a resource, or its limit
+func quantity(c corev1.Container, name corev1.ResourceName) float64 {
+	q, ok := c.Resources.Requests[name]
+	if !ok {
+		q, ok = c.Resources.Limits[name]
+	}
+	if !ok {
+		return 0
+	}
+	return q.AsApproximateFloat64()
+}
+
+// podsPerRun is how many pods a Job runs at once
+func podsPerRun(spec batchv1.JobSpec) int32 {
+	pods := int32(1)
+	if spec.Parallelism != nil {
+		pods = *spec.Parallelism
+	}
+	if spec.Completions != nil && *spec.Completions < pods {
+		pods = *spec.Completions
+	}
+	return pods
+}
+
+// round rounds to cents
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
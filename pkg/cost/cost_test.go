@@ -0,0 +1,152 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEstimate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	prices := PriceSheet{VCPUHour: 0.04, MemoryGBHour: 0.005, GPUHour: 2, Currency: "USD"}
+
+	// finished returns a Job that ran for d, or failed after d
+	finished := func(d time.Duration, failed bool) batchv1.Job {
+		start := metav1.NewTime(now.Add(-time.Hour))
+		end := metav1.NewTime(start.Add(d))
+		job := batchv1.Job{Status: batchv1.JobStatus{StartTime: &start}}
+		if failed {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: end}}
+		} else {
+			job.Status.CompletionTime = &end
+		}
+		return job
+	}
+
+	tests := []struct {
+		name      string
+		schedule  string
+		suspended bool
+		requests  corev1.ResourceList
+		limits    corev1.ResourceList
+		jobSpec   batchv1.JobSpec
+		jobs      []batchv1.Job
+		want      float64
+		wantRuns  int
+		wantBasis string
+	}{
+		{
+			// 30 runs × 1h × (1 vCPU × 0.04 + 2 GiB × 0.005)
+			name:      "Daily, observed durations",
+			schedule:  "0 2 * * *",
+			requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			jobs:      []batchv1.Job{finished(30*time.Minute, false), finished(90*time.Minute, true), {}},
+			want:      1.5,
+			wantRuns:  30,
+			wantBasis: BasisObserved,
+		},
+		{
+			// 720 runs × 5m × 500m vCPU × 0.04
+			name:      "Hourly, assumed duration",
+			schedule:  "@hourly",
+			requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			want:      1.2,
+			wantRuns:  720,
+			wantBasis: BasisAssumed,
+		},
+		{
+			// 4 runs (weekly) × 1h × 3 pods × 1 GPU × 2, from limits
+			name:      "Parallel GPU job",
+			schedule:  "0 0 * * 0",
+			limits:    corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			jobSpec:   batchv1.JobSpec{Parallelism: int32Ptr(3), Completions: int32Ptr(5)},
+			jobs:      []batchv1.Job{finished(time.Hour, false)},
+			want:      24,
+			wantRuns:  4,
+			wantBasis: BasisObserved,
+		},
+		{
+			name:      "Suspended",
+			schedule:  "@hourly",
+			suspended: true,
+			requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			wantBasis: BasisSuspended,
+		},
+		{
+			name:     "Invalid schedule",
+			schedule: "not a schedule",
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	e := NewEstimator(prices, DefaultRunDuration)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{Spec: batchv1.CronJobSpec{
+				Schedule:    tt.schedule,
+				Suspend:     &tt.suspended,
+				JobTemplate: batchv1.JobTemplateSpec{Spec: tt.jobSpec},
+			}}
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{{
+				Name:      "main",
+				Resources: corev1.ResourceRequirements{Requests: tt.requests, Limits: tt.limits},
+			}}
+
+			got := e.Estimate(cronJob, tt.jobs, now)
+			if got.EstimatedMonthlyCost != tt.want || got.MonthlyRuns != tt.wantRuns || got.CostBasis != tt.wantBasis {
+				t.Errorf("Estimate() = %+v; want cost %v, %d runs, basis %q", got, tt.want, tt.wantRuns, tt.wantBasis)
+			}
+		})
+	}
+}
+
+func TestRequests(t *testing.T) {
+	cpu := func(q string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(q)}}
+	}
+	always := corev1.ContainerRestartPolicyAlways
+
+	tests := []struct {
+		name string
+		spec corev1.PodSpec
+		want float64
+	}{
+		{
+			name: "Containers add up",
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Resources: cpu("250m")}, {Resources: cpu("750m")}}},
+			want: 1,
+		},
+		{
+			name: "A bigger init container wins",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: cpu("2")}},
+				Containers:     []corev1.Container{{Resources: cpu("500m")}},
+			},
+			want: 2,
+		},
+		{
+			name: "Sidecars add to the containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: cpu("500m"), RestartPolicy: &always}},
+				Containers:     []corev1.Container{{Resources: cpu("1")}},
+			},
+			want: 1.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _, _ := Requests(&tt.spec); got != tt.want {
+				t.Errorf("Requests() cpu = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
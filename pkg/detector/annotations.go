@@ -0,0 +1,150 @@
+package detector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// Annotations CronJob owners can set to steer detection
+const (
+	// AnnotationIgnore set to "true" marks a CronJob as intentionally dormant
+	AnnotationIgnore = "zombie-hunter.io/ignore"
+	// AnnotationIgnoreUntil acknowledges a CronJob up to and including a date
+	// (2006-01-02) or until a time (RFC 3339)
+	AnnotationIgnoreUntil = "zombie-hunter.io/ignore-until"
+	// AnnotationThresholdDays overrides the day threshold for one CronJob
+	AnnotationThresholdDays = "zombie-hunter.io/threshold-days"
+	// AnnotationOwner names the team or person responsible for the CronJob
+	AnnotationOwner = "zombie-hunter.io/owner"
+
+	// Set by zombie-hunter quarantine on suspended zombies
+	AnnotationQuarantinedAt    = "zombie-hunter.io/quarantined-at"
+	AnnotationQuarantinedBy    = "zombie-hunter.io/quarantined-by"
+	AnnotationQuarantineReason = "zombie-hunter.io/quarantine-reason"
+	AnnotationDeleteAfter      = "zombie-hunter.io/delete-after"
+	// AnnotationRescuedUntil is set by zombie-hunter reap on quarantined
+	// CronJobs a human unsuspended; they are not reported until then
+	AnnotationRescuedUntil = "zombie-hunter.io/rescued-until"
+)
+
+// Hints are the zombie-hunter annotations found on a CronJob
+type Hints struct {
+	Ignore      bool
+	IgnoreUntil *time.Time // exclusive end of the acknowledgement
+	// IgnoreUntilDate is set when IgnoreUntil was given as a bare date, so
+	// it is the midnight (UTC) after that day
+	IgnoreUntilDate bool
+	ThresholdDays   int // 0 when not overridden
+	Owner           string
+	Invalid         []string // annotations that could not be parsed
+
+	QuarantinedAt *time.Time
+	DeleteAfter   *time.Time
+	RescuedUntil  *time.Time
+}
+
+// ParseHints reads the zombie-hunter annotations on a CronJob. Malformed
+// values are reported in Invalid and otherwise ignored.
+func ParseHints(cronJob *batchv1.CronJob) Hints {
+	var h Hints
+	annotations := cronJob.Annotations
+
+	if v, ok := annotations[AnnotationIgnore]; ok {
+		ignore, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			h.Invalid = append(h.Invalid, fmt.Sprintf("%s: %q is not a boolean", AnnotationIgnore, v))
+		}
+		h.Ignore = ignore
+	}
+
+	if v, ok := annotations[AnnotationIgnoreUntil]; ok {
+		until, dateOnly, err := parseUntil(strings.TrimSpace(v))
+		if err != nil {
+			h.Invalid = append(h.Invalid, fmt.Sprintf("%s: %q is not a date (2006-01-02) or RFC 3339 time", AnnotationIgnoreUntil, v))
+		} else {
+			h.IgnoreUntil, h.IgnoreUntilDate = &until, dateOnly
+		}
+	}
+
+	if v, ok := annotations[AnnotationThresholdDays]; ok {
+		days, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || days < 1 {
+			h.Invalid = append(h.Invalid, fmt.Sprintf("%s: %q is not a positive number of days", AnnotationThresholdDays, v))
+		} else {
+			h.ThresholdDays = days
+		}
+	}
+
+	h.Owner = strings.TrimSpace(annotations[AnnotationOwner])
+
+	times := []struct {
+		key   string
+		field **time.Time
+	}{
+		{AnnotationQuarantinedAt, &h.QuarantinedAt},
+		{AnnotationDeleteAfter, &h.DeleteAfter},
+		{AnnotationRescuedUntil, &h.RescuedUntil},
+	}
+	for _, a := range times {
+		key, field := a.key, a.field
+		v, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(v))
+		if err != nil {
+			h.Invalid = append(h.Invalid, fmt.Sprintf("%s: %q is not an RFC 3339 time", key, v))
+			continue
+		}
+		*field = &t
+	}
+
+	return h
+}
+
+// Acknowledged reports whether the owner has asked for the CronJob to be
+// left alone at the given time
+func (h Hints) Acknowledged(now time.Time) bool {
+	return h.Ignore || (h.IgnoreUntil != nil && now.Before(*h.IgnoreUntil))
+}
+
+// Rescued reports whether the CronJob was taken out of quarantine by a
+// human and is still within its rescue period
+func (h Hints) Rescued(now time.Time) bool {
+	return h.RescuedUntil != nil && now.Before(*h.RescuedUntil)
+}
+
+// Quarantined reports whether zombie-hunter quarantine marked the CronJob
+func (h Hints) Quarantined() bool {
+	return h.QuarantinedAt != nil
+}
+
+// parseUntil accepts a bare date, meaning the whole of that day in UTC, or a
+// full RFC 3339 time, and reports which it was
+func parseUntil(s string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t.AddDate(0, 0, 1), true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, false, err
+}
+
+// AcknowledgedBy names what excused an acknowledged CronJob: the
+// annotation that applied, or the rule that returned the ignore verdict
+func (h Hints) AcknowledgedBy(s Signal, now time.Time) string {
+	if s.Rule != RuleAnnotations {
+		return "rule " + s.Rule
+	}
+	switch {
+	case h.Ignore:
+		return AnnotationIgnore
+	case h.IgnoreUntil != nil && h.Acknowledged(now):
+		return AnnotationIgnoreUntil
+	default:
+		return AnnotationRescuedUntil
+	}
+}
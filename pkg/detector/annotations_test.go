@@ -0,0 +1,213 @@
+package detector
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseHints(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedUntil string
+		expectedDays  int
+		expectedOwner string
+		expectedBad   int
+	}{
+		{
+			name:        "No annotations",
+			annotations: nil,
+		},
+		{
+			name: "All annotations",
+			annotations: map[string]string{
+				AnnotationIgnoreUntil:   "2026-12-31",
+				AnnotationThresholdDays: "180",
+				AnnotationOwner:         " team-x ",
+			},
+			expectedUntil: "2027-01-01T00:00:00Z",
+			expectedDays:  180,
+			expectedOwner: "team-x",
+		},
+		{
+			name:          "RFC 3339 ignore-until",
+			annotations:   map[string]string{AnnotationIgnoreUntil: "2026-12-31T12:00:00Z"},
+			expectedUntil: "2026-12-31T12:00:00Z",
+		},
+		{
+			name: "Malformed values are reported",
+			annotations: map[string]string{
+				AnnotationIgnore:        "yes please",
+				AnnotationIgnoreUntil:   "next year",
+				AnnotationThresholdDays: "0",
+			},
+			expectedBad: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := ParseHints(&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}})
+
+			until := ""
+			if h.IgnoreUntil != nil {
+				until = h.IgnoreUntil.Format(time.RFC3339)
+			}
+			if until != tt.expectedUntil {
+				t.Errorf("IgnoreUntil = %q; want %q", until, tt.expectedUntil)
+			}
+			if h.ThresholdDays != tt.expectedDays {
+				t.Errorf("ThresholdDays = %d; want %d", h.ThresholdDays, tt.expectedDays)
+			}
+			if h.Owner != tt.expectedOwner {
+				t.Errorf("Owner = %q; want %q", h.Owner, tt.expectedOwner)
+			}
+			if len(h.Invalid) != tt.expectedBad {
+				t.Errorf("Invalid = %v; want %d entries", h.Invalid, tt.expectedBad)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCronJobAnnotations(t *testing.T) {
+	now := time.Now()
+	tomorrow := now.AddDate(0, 0, 1).Format(time.DateOnly)
+	lastWeek := now.AddDate(0, 0, -7).Format(time.DateOnly)
+
+	tests := []struct {
+		name                 string
+		annotations          map[string]string
+		lastSuccess          time.Time
+		expectedIsZombie     bool
+		expectedAcknowledged bool
+		expectedBy           string
+	}{
+		{
+			name:             "Stale daily job without annotations",
+			lastSuccess:      now.AddDate(0, 0, -40),
+			expectedIsZombie: true,
+		},
+		{
+			name:                 "Ignored stale job is acknowledged",
+			annotations:          map[string]string{AnnotationIgnore: "true"},
+			lastSuccess:          now.AddDate(0, 0, -40),
+			expectedAcknowledged: true,
+			expectedBy:           AnnotationIgnore,
+		},
+		{
+			name:                 "Ignore-until in the future",
+			annotations:          map[string]string{AnnotationIgnoreUntil: tomorrow},
+			lastSuccess:          now.AddDate(0, 0, -40),
+			expectedAcknowledged: true,
+			expectedBy:           AnnotationIgnoreUntil,
+		},
+		{
+			name:             "Expired ignore-until",
+			annotations:      map[string]string{AnnotationIgnoreUntil: lastWeek},
+			lastSuccess:      now.AddDate(0, 0, -40),
+			expectedIsZombie: true,
+		},
+		{
+			name:             "Ignored healthy job is not acknowledged",
+			annotations:      map[string]string{AnnotationIgnore: "true"},
+			lastSuccess:      now.AddDate(0, 0, -1),
+			expectedIsZombie: false,
+		},
+		{
+			name:                 "Rescued from quarantine",
+			annotations:          map[string]string{AnnotationRescuedUntil: now.AddDate(0, 1, 0).UTC().Format(time.RFC3339)},
+			lastSuccess:          now.AddDate(0, 0, -40),
+			expectedAcknowledged: true,
+			expectedBy:           AnnotationRescuedUntil,
+		},
+		{
+			name:             "Rescue period over",
+			annotations:      map[string]string{AnnotationRescuedUntil: now.AddDate(0, 0, -1).UTC().Format(time.RFC3339)},
+			lastSuccess:      now.AddDate(0, 0, -40),
+			expectedIsZombie: true,
+		},
+		{
+			name:             "Longer threshold keeps a 40-day gap healthy",
+			annotations:      map[string]string{AnnotationThresholdDays: "180"},
+			lastSuccess:      now.AddDate(0, 0, -40),
+			expectedIsZombie: false,
+		},
+		{
+			name:             "Shorter threshold flags a 10-day gap",
+			annotations:      map[string]string{AnnotationThresholdDays: "7"},
+			lastSuccess:      now.AddDate(0, 0, -10),
+			expectedIsZombie: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "job",
+					Namespace:         "default",
+					Annotations:       tt.annotations,
+					CreationTimestamp: metav1.NewTime(now.AddDate(-1, 0, 0)),
+				},
+				Spec: batchv1.CronJobSpec{Schedule: "@daily"},
+			}
+
+			zombie := AnalyzeCronJob(cronJob, []batchv1.Job{completedJob(tt.lastSuccess)}, 30)
+			if zombie.IsZombie != tt.expectedIsZombie {
+				t.Errorf("IsZombie = %v; want %v (missed %d of %d runs)",
+					zombie.IsZombie, tt.expectedIsZombie, zombie.MissedRuns, zombie.ExpectedRuns)
+			}
+			if zombie.Acknowledged != tt.expectedAcknowledged {
+				t.Errorf("Acknowledged = %v; want %v", zombie.Acknowledged, tt.expectedAcknowledged)
+			}
+			if zombie.AcknowledgedBy != tt.expectedBy {
+				t.Errorf("AcknowledgedBy = %q; want %q", zombie.AcknowledgedBy, tt.expectedBy)
+			}
+			if zombie.Acknowledged && zombie.Confidence == 0 {
+				t.Errorf("Confidence of an acknowledged zombie = 0; want the score it would have had")
+			}
+		})
+	}
+}
+
+func TestFormatUntil(t *testing.T) {
+	tests := []struct {
+		annotation string
+		expected   string
+	}{
+		{annotation: "2026-12-31", expected: "2026-12-31"},
+		{annotation: "2026-12-31T00:00:00Z", expected: "2026-12-31T00:00:00Z"},
+		{annotation: "2026-12-31T00:00:00+02:00", expected: "2026-12-31T00:00:00+02:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.annotation, func(t *testing.T) {
+			h := ParseHints(&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationIgnoreUntil: tt.annotation}}})
+			if got := formatUntil(*h.IgnoreUntil, h.IgnoreUntilDate); got != tt.expected {
+				t.Errorf("formatUntil() = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCronJobOwner(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "job",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationOwner: "team-x"},
+		},
+		Spec: batchv1.CronJobSpec{Schedule: "@daily"},
+	}
+
+	zombie := AnalyzeCronJob(cronJob, nil, 30)
+	if zombie.Owner != "team-x" {
+		t.Errorf("Owner = %q; want %q", zombie.Owner, "team-x")
+	}
+	if want := "annotation " + AnnotationOwner; zombie.OwnerSource != want {
+		t.Errorf("OwnerSource = %q; want %q", zombie.OwnerSource, want)
+	}
+}
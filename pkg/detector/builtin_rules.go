@@ -0,0 +1,266 @@
+package detector
+
+import (
+	"fmt"
+	"time"
+)
+
+// Built-in rule names
+const (
+	RuleSuspended   = "suspended"
+	RuleNeverRan    = "never-ran"
+	RuleFailedJobs  = "failed-jobs"
+	RuleInactivity  = "inactivity"
+	RuleMissedRuns  = "missed-runs"
+	RuleHistory     = "history"
+	RuleAnnotations = "annotations"
+)
+
+// builtinRules returns the default heuristics, in evaluation order
+func builtinRules() []Rule {
+	return []Rule{
+		NewRule(RuleSuspended, suspendedRule),
+		NewRule(RuleNeverRan, neverRanRule),
+		NewRule(RuleFailedJobs, failedJobsRule),
+		NewRule(RuleInactivity, inactivityRule),
+		NewRule(RuleMissedRuns, missedRunsRule),
+		NewRule(RuleHistory, historyRule),
+		NewRule(RuleAnnotations, annotationsRule),
+	}
+}
+
+// suspendedRule lowers the score of CronJobs that were paused on purpose
+func suspendedRule(in *Input) []Signal {
+	if !in.Suspended {
+		return nil
+	}
+
+	observed := "true"
+	message := "CronJob is suspended (spec.suspend: true), probably paused on purpose"
+	if in.LastSchedule != nil {
+		observed = "last scheduled " + in.LastSchedule.Format("2006-01-02")
+		message = "suspended since at least " + in.LastSchedule.Format("2006-01-02") + ", probably paused on purpose"
+	}
+
+	return []Signal{{
+		Name:     SignalSuspended,
+		Weight:   -65,
+		Observed: observed,
+		Message:  message,
+	}}
+}
+
+// neverRanRule flags CronJobs with no sign of ever having run, once they
+// are past their grace period. With a known schedule the missed-runs rule
+// decides instead.
+func neverRanRule(in *Input) []Signal {
+	if in.HasRun || in.New {
+		return nil
+	}
+
+	s := Signal{
+		Name:     SignalNeverRan,
+		Weight:   50,
+		Observed: "0 jobs",
+		Message:  "no jobs or status show this CronJob ever ran; it could be new or abandoned",
+	}
+	if in.Schedule == nil {
+		s.Verdict = VerdictZombie
+	}
+	return []Signal{s}
+}
+
+// failedJobsRule reports failed jobs among the retained history
+func failedJobsRule(in *Input) []Signal {
+	if in.FailedJobs == 0 {
+		return nil
+	}
+
+	if in.FailedJobs == in.TotalJobs {
+		return []Signal{{
+			Name:     SignalAllFailed,
+			Weight:   95,
+			Observed: fmt.Sprintf("%d/%d", in.FailedJobs, in.TotalJobs),
+			Message:  fmt.Sprintf("all %d retained jobs failed", in.TotalJobs),
+		}}
+	}
+
+	return []Signal{{
+		Name:     SignalFailedJobs,
+		Weight:   0,
+		Observed: fmt.Sprintf("%d/%d", in.FailedJobs, in.TotalJobs),
+		Message:  fmt.Sprintf("%d of %d retained jobs failed", in.FailedJobs, in.TotalJobs),
+	}}
+}
+
+// inactivityRule scores time since the last success. Without a known
+// schedule it also decides the verdict against the day threshold.
+func inactivityRule(in *Input) []Signal {
+	allFailed := in.TotalJobs > 0 && in.FailedJobs == in.TotalJobs
+	if !in.HasRun || (in.DaysSinceSuccess >= 999 && allFailed) {
+		return nil
+	}
+
+	observed, message := "never", "no successful run on record"
+	if in.DaysSinceSuccess < 999 {
+		observed = fmt.Sprintf("%d days", in.DaysSinceSuccess)
+		message = fmt.Sprintf("no successful run in %d days", in.DaysSinceSuccess)
+		if in.LastSuccess != nil {
+			message = fmt.Sprintf("last successful run %s (%d days ago)", in.LastSuccess.Format("2006-01-02"), in.DaysSinceSuccess)
+		}
+	}
+
+	s := Signal{
+		Name:     SignalInactivity,
+		Weight:   inactivityWeight(in.DaysSinceSuccess),
+		Observed: observed,
+		Message:  message,
+	}
+	if in.Schedule == nil && in.DaysSinceSuccess >= in.ThresholdDays {
+		s.Verdict = VerdictZombie
+	}
+	return []Signal{s}
+}
+
+// missedRunsRule compares the schedule with the runs that actually succeeded
+func missedRunsRule(in *Input) []Signal {
+	if in.Schedule == nil || in.Schedule.ExpectedRuns == 0 || in.New {
+		return nil
+	}
+
+	s := Signal{
+		Name:     SignalMissedRuns,
+		Observed: fmt.Sprintf("%d/%d", in.Schedule.MissedRuns, in.Schedule.ExpectedRuns),
+		Message: fmt.Sprintf("missed %d of %d expected runs (%.0f%%)",
+			in.Schedule.MissedRuns, in.Schedule.ExpectedRuns, 100*in.Schedule.MissedRatio()),
+	}
+	if in.HasRun {
+		s.Weight = missedRunConfidence(*in.Schedule)
+	}
+	if in.Schedule.MissedRuns > 0 && in.Schedule.MissedRatio() >= MissedRatioThreshold {
+		s.Verdict = VerdictZombie
+	}
+	return []Signal{s}
+}
+
+// historyRule notes where the evidence came from and whether jobs are running
+func historyRule(in *Input) []Signal {
+	var signals []Signal
+
+	if in.EvidenceSource == EvidenceCronJobStatus {
+		signals = append(signals, Signal{
+			Name:     SignalEvidence,
+			Weight:   0,
+			Observed: EvidenceCronJobStatus,
+			Message:  "job history is gone; using CronJob status fields as evidence",
+		})
+	}
+
+	if in.ActiveJobs > 0 {
+		signals = append(signals, Signal{
+			Name:     SignalActive,
+			Weight:   0,
+			Observed: fmt.Sprintf("%d", in.ActiveJobs),
+			Message:  fmt.Sprintf("%d job(s) currently running", in.ActiveJobs),
+		})
+	}
+
+	return signals
+}
+
+// annotationsRule honours the zombie-hunter.io/* annotations: it excuses
+// CronJobs their owners acknowledged and notes overrides and typos
+func annotationsRule(in *Input) []Signal {
+	var signals []Signal
+	h := in.Hints
+
+	switch {
+	case h.Ignore:
+		signals = append(signals, Signal{
+			Name:     SignalAcknowledged,
+			Observed: AnnotationIgnore,
+			Message:  "owner marked this CronJob as intentionally dormant",
+			Verdict:  VerdictIgnore,
+		})
+	case h.IgnoreUntil != nil && h.Acknowledged(in.Now):
+		signals = append(signals, Signal{
+			Name:     SignalAcknowledged,
+			Observed: "until " + formatUntil(*h.IgnoreUntil, h.IgnoreUntilDate),
+			Message:  "owner acknowledged this CronJob until " + formatUntil(*h.IgnoreUntil, h.IgnoreUntilDate),
+			Verdict:  VerdictIgnore,
+		})
+	case h.Rescued(in.Now):
+		signals = append(signals, Signal{
+			Name:     SignalAcknowledged,
+			Observed: "rescued until " + h.RescuedUntil.Format(time.DateOnly),
+			Message:  "rescued from quarantine; not reported until " + h.RescuedUntil.Format(time.DateOnly),
+			Verdict:  VerdictIgnore,
+		})
+	case h.IgnoreUntil != nil:
+		signals = append(signals, Signal{
+			Name:     SignalAnnotation,
+			Observed: "expired " + formatUntil(*h.IgnoreUntil, h.IgnoreUntilDate),
+			Message:  "acknowledgement expired on " + formatUntil(*h.IgnoreUntil, h.IgnoreUntilDate),
+		})
+	}
+
+	if h.Quarantined() {
+		message := "quarantined on " + h.QuarantinedAt.Format(time.DateOnly)
+		if h.DeleteAfter != nil {
+			message += ", deleted by reap after " + h.DeleteAfter.Format(time.DateOnly)
+		}
+		signals = append(signals, Signal{
+			Name:     SignalQuarantined,
+			Observed: h.QuarantinedAt.Format(time.DateOnly),
+			Message:  message,
+		})
+	}
+
+	if h.ThresholdDays > 0 {
+		signals = append(signals, Signal{
+			Name:     SignalAnnotation,
+			Observed: fmt.Sprintf("threshold %d days", h.ThresholdDays),
+			Message:  fmt.Sprintf("threshold overridden to %d days by %s", h.ThresholdDays, AnnotationThresholdDays),
+		})
+	}
+
+	for _, problem := range h.Invalid {
+		signals = append(signals, Signal{
+			Name:     SignalAnnotation,
+			Observed: "invalid",
+			Message:  "ignored invalid annotation " + problem,
+		})
+	}
+
+	return signals
+}
+
+// formatUntil shows the last acknowledged day for date-only annotations and
+// the exact time, in its own offset, otherwise
+func formatUntil(until time.Time, dateOnly bool) string {
+	if dateOnly {
+		return until.AddDate(0, 0, -1).Format(time.DateOnly)
+	}
+	return until.Format(time.RFC3339)
+}
+
+// inactivityWeight maps days since the last success to a confidence
+func inactivityWeight(daysSince int) int {
+	if daysSince >= 365 {
+		return 99
+	}
+	if daysSince >= 180 {
+		return 95
+	}
+	if daysSince >= 90 {
+		return 85
+	}
+	if daysSince >= 60 {
+		return 75
+	}
+	if daysSince >= 30 {
+		return 60
+	}
+
+	return 40
+}
@@ -9,6 +9,7 @@ import (
 
 // Zombie represents a potentially abandoned CronJob
 type Zombie struct {
+	Cluster          string // kubeconfig context, set by the caller
 	Name             string
 	Namespace        string
 	Schedule         string
@@ -18,71 +19,199 @@ type Zombie struct {
 	FailedJobs       int
 	IsSuspended      bool
 	IsZombie         bool
+	ExpectedRuns     int
+	MissedRuns       int
+	NextScheduledRun *time.Time
+	LastSuccessTime  *time.Time
+	LastScheduleTime *time.Time
+	ActiveJobs       int
+	EvidenceSource   string
+	Owner            string
+	OwnerSource      string // the rule that resolved Owner, e.g. "label team"
+	Acknowledged     bool   // would be a zombie, but a rule such as zombie-hunter.io/ignore excused it
+	AcknowledgedBy   string // what excused it, e.g. "zombie-hunter.io/ignore-until" or "rule dr-jobs"
+	Signals          []Signal
+	Provenance       // set by the caller, see k8s.ProvenanceOf
+	CostEstimate     // set by the caller, see cost.Estimator
 }
 
-// AnalyzeCronJob analyzes a CronJob and its Jobs to determine if it's a zombie
+// CostEstimate is what a CronJob's runs cost per month at the current
+// schedule, resource requests and observed run durations
+type CostEstimate struct {
+	MonthlyRuns          int
+	RunDuration          time.Duration // average of the retained Jobs, or the configured default
+	MonthlyCPUHours      float64
+	MonthlyMemoryGBHours float64
+	MonthlyGPUHours      float64
+	EstimatedMonthlyCost float64
+	CostBasis            string // "observed", "assumed" (no Job durations retained) or "suspended"
+}
+
+// Provenance is who created and last changed a CronJob, and the tool that
+// manages it, if any
+type Provenance struct {
+	CreatedBy      string // field manager, e.g. "kubectl-client-side-apply" or "helm"
+	LastModifiedBy string
+	LastModifiedAt *time.Time
+	ManagedBy      string // e.g. "Helm", "Argo CD" or "Flux"; deleting such CronJobs in-cluster is usually undone
+	// GitOps is set when Argo CD, Flux or Helm owns the CronJob, so it
+	// should be removed at its source rather than in the cluster
+	GitOps *GitOpsSource `json:",omitempty"`
+}
+
+// GitOpsSource is the object a GitOps tool or Helm recreates a CronJob from
+type GitOpsSource struct {
+	Tool      string // "Argo CD", "Flux" or "Helm"
+	Kind      string // "Application", "Kustomization", "HelmRelease" or "release"
+	Namespace string // empty when the tool doesn't record it
+	Name      string // empty when only the tool's field manager was seen
+	Action    string // the suggested change at the source
+}
+
+// String names the source, e.g. "Argo CD Application argocd/billing"
+func (s GitOpsSource) String() string {
+	name := s.Name
+	switch {
+	case name == "":
+		name = "(name unknown)"
+	case s.Namespace != "":
+		name = s.Namespace + "/" + name
+	}
+	return s.Tool + " " + s.Kind + " " + name
+}
+
+// Detector classifies CronJobs by evaluating a set of rules
+type Detector struct {
+	rules         *Registry
+	thresholdDays int
+}
+
+// NewDetector creates a detector using the given rules
+func NewDetector(rules *Registry, thresholdDays int) *Detector {
+	return &Detector{rules: rules, thresholdDays: thresholdDays}
+}
+
+// AnalyzeCronJob analyzes a CronJob and its Jobs with the built-in rules
 func AnalyzeCronJob(cronJob *batchv1.CronJob, jobs []batchv1.Job, thresholdDays int) Zombie {
-	daysSince := DaysSinceSuccess(jobs)
-	totalJobs := len(jobs)
-	failedJobs := countFailedJobs(jobs)
-	isSuspended := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+	return NewDetector(DefaultRegistry(), thresholdDays).Analyze(cronJob, jobs)
+}
+
+// Analyze determines whether a CronJob is a zombie. When the schedule can be
+// parsed the built-in verdict is based on missed runs, so a yearly job is not
+// flagged after 30 quiet days while a frequent job that has been failing for
+// most of the window is. Otherwise it falls back to days. A zombie whose
+// owner acknowledged it through annotations is returned with Acknowledged set
+// instead of IsZombie.
+func (d *Detector) Analyze(cronJob *batchv1.CronJob, jobs []batchv1.Job) Zombie {
+	in := d.newInput(cronJob, jobs, time.Now())
 
 	zombie := Zombie{
 		Name:             cronJob.Name,
 		Namespace:        cronJob.Namespace,
 		Schedule:         cronJob.Spec.Schedule,
-		DaysSinceSuccess: daysSince,
-		TotalJobs:        totalJobs,
-		FailedJobs:       failedJobs,
-		IsSuspended:      isSuspended,
+		DaysSinceSuccess: in.DaysSinceSuccess,
+		TotalJobs:        in.TotalJobs,
+		FailedJobs:       in.FailedJobs,
+		IsSuspended:      in.Suspended,
 		IsZombie:         false,
 		Confidence:       0,
+		LastSuccessTime:  in.LastSuccess,
+		LastScheduleTime: in.LastSchedule,
+		ActiveJobs:       in.ActiveJobs,
+		EvidenceSource:   in.EvidenceSource,
+		Owner:            in.Hints.Owner,
+	}
+	if zombie.Owner != "" {
+		zombie.OwnerSource = "annotation " + AnnotationOwner
 	}
 
-	// Determine if it's a zombie
-	if daysSince >= thresholdDays || totalJobs == 0 {
-		zombie.IsZombie = true
-		zombie.Confidence = CalculateConfidence(daysSince, totalJobs, failedJobs, isSuspended)
+	if in.Schedule != nil {
+		zombie.ExpectedRuns = in.Schedule.ExpectedRuns
+		zombie.MissedRuns = in.Schedule.MissedRuns
+		zombie.NextScheduledRun = in.Schedule.NextScheduledRun
 	}
 
-	return zombie
-}
+	zombie.Signals = d.rules.Evaluate(in)
 
-// CalculateConfidence calculates confidence score (0-99%) that a CronJob is abandoned
-func CalculateConfidence(daysSince, totalJobs, failedJobs int, suspended bool) int {
-	// Suspended jobs are intentionally paused - low confidence
-	if suspended {
-		return 20
+	// Any rule can flag a zombie, and any rule can veto the report
+	var ignoredBy string
+	for _, s := range zombie.Signals {
+		switch s.Verdict {
+		case VerdictZombie:
+			zombie.IsZombie = true
+		case VerdictIgnore:
+			if ignoredBy == "" {
+				ignoredBy = in.Hints.AcknowledgedBy(s, in.Now)
+			}
+		}
 	}
-
-	// Never ran - could be new or abandoned
-	if totalJobs == 0 {
-		return 50
+	if ignoredBy != "" && zombie.IsZombie {
+		zombie.IsZombie = false
+		zombie.Acknowledged = true
+		zombie.AcknowledgedBy = ignoredBy
 	}
 
-	// All jobs failed - clearly broken
-	if totalJobs > 0 && failedJobs == totalJobs {
-		return 95
+	if zombie.IsZombie || zombie.Acknowledged {
+		zombie.Confidence = Score(zombie.Signals)
 	}
 
-	// Time-based confidence scoring
-	if daysSince >= 365 {
-		return 99
-	}
-	if daysSince >= 180 {
-		return 95
-	}
-	if daysSince >= 90 {
-		return 85
+	return zombie
+}
+
+// newInput derives the facts rules work from
+func (d *Detector) newInput(cronJob *batchv1.CronJob, jobs []batchv1.Job, now time.Time) *Input {
+	ev := collectEvidence(cronJob, jobs)
+	hints := ParseHints(cronJob)
+
+	thresholdDays := d.thresholdDays
+	if hints.ThresholdDays > 0 {
+		thresholdDays = hints.ThresholdDays
 	}
-	if daysSince >= 60 {
-		return 75
+
+	in := &Input{
+		CronJob:          cronJob,
+		Jobs:             jobs,
+		ThresholdDays:    thresholdDays,
+		Now:              now,
+		DaysSinceSuccess: daysSinceTime(ev.lastSuccess, now),
+		TotalJobs:        len(jobs),
+		FailedJobs:       countFailedJobs(jobs),
+		Suspended:        cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		HasRun:           ev.hasRun(),
+		LastSuccess:      ev.lastSuccess,
+		LastSchedule:     ev.lastSchedule,
+		ActiveJobs:       ev.active,
+		EvidenceSource:   ev.source,
+		Hints:            hints,
 	}
-	if daysSince >= 30 {
-		return 60
+
+	grace := time.Duration(thresholdDays) * 24 * time.Hour
+	if sched, err := ParseSchedule(cronJob); err == nil {
+		stats := AnalyzeSchedule(sched, cronJob.CreationTimestamp.Time, ev.lastSuccess, thresholdDays, now)
+		if ev.active > 0 && stats.MissedRuns > 0 {
+			// The latest run is still in progress, not missed
+			stats.MissedRuns--
+		}
+		in.Schedule = &stats
+		if period := schedulePeriod(sched, now); 2*period > grace {
+			grace = 2 * period
+		}
 	}
+	created := cronJob.CreationTimestamp.Time
+	in.New = !in.HasRun && !created.IsZero() && now.Before(created.Add(grace))
 
-	return 40
+	return in
+}
+
+// CalculateConfidence calculates confidence score (0-99%) that a CronJob is abandoned
+func CalculateConfidence(daysSince, totalJobs, failedJobs int, suspended bool) int {
+	return Score(DefaultRegistry().Evaluate(&Input{
+		DaysSinceSuccess: daysSince,
+		TotalJobs:        totalJobs,
+		FailedJobs:       failedJobs,
+		Suspended:        suspended,
+		HasRun:           totalJobs > 0,
+	}))
 }
 
 // DaysSinceSuccess calculates days since last successful job completion
@@ -91,6 +220,11 @@ func DaysSinceSuccess(jobs []batchv1.Job) int {
 		return 999 // Never ran
 	}
 
+	return daysSinceTime(lastSuccessTime(jobs), time.Now())
+}
+
+// lastSuccessTime returns when the most recent successful job completed
+func lastSuccessTime(jobs []batchv1.Job) *time.Time {
 	var lastSuccess *time.Time
 
 	for _, job := range jobs {
@@ -104,12 +238,7 @@ func DaysSinceSuccess(jobs []batchv1.Job) int {
 		}
 	}
 
-	if lastSuccess == nil {
-		return 999 // No successful jobs
-	}
-
-	days := int(time.Since(*lastSuccess).Hours() / 24)
-	return days
+	return lastSuccess
 }
 
 // countFailedJobs counts how many jobs have failed
@@ -0,0 +1,72 @@
+package detector
+
+import (
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// Evidence sources recorded on a Zombie
+const (
+	EvidenceJobs          = "jobs"           // retained Job objects
+	EvidenceCronJobStatus = "cronjob-status" // CronJob status fields only
+	EvidenceNone          = "none"           // nothing shows the CronJob ever ran
+)
+
+// evidence is what is known about a CronJob's past runs
+type evidence struct {
+	lastSuccess  *time.Time
+	lastSchedule *time.Time
+	active       int
+	source       string
+}
+
+// hasRun reports whether anything shows the CronJob was ever scheduled
+func (e evidence) hasRun() bool {
+	return e.source != EvidenceNone
+}
+
+// collectEvidence merges Job conditions with the CronJob status. Job history
+// is often gone (successfulJobsHistoryLimit: 0, ttlSecondsAfterFinished), but
+// status.lastSuccessfulTime and status.lastScheduleTime survive it.
+func collectEvidence(cronJob *batchv1.CronJob, jobs []batchv1.Job) evidence {
+	ev := evidence{
+		lastSuccess: lastSuccessTime(jobs),
+		active:      len(cronJob.Status.Active),
+		source:      EvidenceNone,
+	}
+
+	if len(jobs) > 0 {
+		ev.source = EvidenceJobs
+	}
+
+	if t := cronJob.Status.LastSuccessfulTime; t != nil {
+		if ev.lastSuccess == nil || t.Time.After(*ev.lastSuccess) {
+			success := t.Time
+			ev.lastSuccess = &success
+			ev.source = EvidenceCronJobStatus
+		}
+	}
+
+	if t := cronJob.Status.LastScheduleTime; t != nil {
+		scheduled := t.Time
+		ev.lastSchedule = &scheduled
+		if ev.source == EvidenceNone {
+			ev.source = EvidenceCronJobStatus
+		}
+	}
+
+	if ev.active > 0 && ev.source == EvidenceNone {
+		ev.source = EvidenceCronJobStatus
+	}
+
+	return ev
+}
+
+// daysSinceTime returns whole days since t, or 999 when t is unknown
+func daysSinceTime(t *time.Time, now time.Time) int {
+	if t == nil {
+		return 999
+	}
+	return int(now.Sub(*t).Hours() / 24)
+}
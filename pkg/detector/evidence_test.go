@@ -0,0 +1,126 @@
+package detector
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnalyzeCronJobStatusEvidence(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		status           batchv1.CronJobStatus
+		jobs             []batchv1.Job
+		expectedIsZombie bool
+		expectedEvidence string
+		expectedDays     int
+	}{
+		{
+			name: "Job history gone but status shows success yesterday",
+			status: batchv1.CronJobStatus{
+				LastScheduleTime:   timeRef(now.Add(-24 * time.Hour)),
+				LastSuccessfulTime: timeRef(now.Add(-24 * time.Hour)),
+			},
+			expectedIsZombie: false,
+			expectedEvidence: EvidenceCronJobStatus,
+			expectedDays:     1,
+		},
+		{
+			name: "Status success is newer than retained jobs",
+			status: batchv1.CronJobStatus{
+				LastSuccessfulTime: timeRef(now.Add(-2 * 24 * time.Hour)),
+			},
+			jobs:             []batchv1.Job{completedJob(now.Add(-50 * 24 * time.Hour))},
+			expectedIsZombie: false,
+			expectedEvidence: EvidenceCronJobStatus,
+			expectedDays:     2,
+		},
+		{
+			name: "Retained jobs are newer than status",
+			status: batchv1.CronJobStatus{
+				LastSuccessfulTime: timeRef(now.Add(-50 * 24 * time.Hour)),
+			},
+			jobs:             []batchv1.Job{completedJob(now.Add(-3 * 24 * time.Hour))},
+			expectedIsZombie: false,
+			expectedEvidence: EvidenceJobs,
+			expectedDays:     3,
+		},
+		{
+			name:             "No jobs and no status is a zombie",
+			expectedIsZombie: true,
+			expectedEvidence: EvidenceNone,
+			expectedDays:     999,
+		},
+		{
+			name: "Old status success is still a zombie",
+			status: batchv1.CronJobStatus{
+				LastScheduleTime:   timeRef(now.Add(-100 * 24 * time.Hour)),
+				LastSuccessfulTime: timeRef(now.Add(-100 * 24 * time.Hour)),
+			},
+			expectedIsZombie: true,
+			expectedEvidence: EvidenceCronJobStatus,
+			expectedDays:     100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "ttl-job", Namespace: "default"},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *"},
+				Status:     tt.status,
+			}
+
+			zombie := AnalyzeCronJob(cronJob, tt.jobs, 30)
+			if zombie.IsZombie != tt.expectedIsZombie {
+				t.Errorf("IsZombie = %v; want %v", zombie.IsZombie, tt.expectedIsZombie)
+			}
+			if zombie.EvidenceSource != tt.expectedEvidence {
+				t.Errorf("EvidenceSource = %q; want %q", zombie.EvidenceSource, tt.expectedEvidence)
+			}
+			if zombie.DaysSinceSuccess != tt.expectedDays {
+				t.Errorf("DaysSinceSuccess = %d; want %d", zombie.DaysSinceSuccess, tt.expectedDays)
+			}
+		})
+	}
+}
+
+func TestStatusEvidenceIsNotNeverRan(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "ttl-job", Namespace: "default"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *"},
+		Status: batchv1.CronJobStatus{
+			LastScheduleTime:   timeRef(time.Now().Add(-200 * 24 * time.Hour)),
+			LastSuccessfulTime: timeRef(time.Now().Add(-200 * 24 * time.Hour)),
+		},
+	}
+
+	zombie := AnalyzeCronJob(cronJob, nil, 30)
+	if zombie.Confidence != 95 {
+		t.Errorf("Confidence = %d; want 95 (180-365 days), not the never-ran score", zombie.Confidence)
+	}
+}
+
+func completedJob(at time.Time) batchv1.Job {
+	return batchv1.Job{
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:               batchv1.JobComplete,
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(at),
+				},
+			},
+		},
+	}
+}
+
+func timeRef(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
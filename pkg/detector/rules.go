@@ -0,0 +1,167 @@
+package detector
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// Input is everything a Rule can look at for one CronJob: the objects
+// themselves, the scan context and the facts the detector derived from them.
+// ThresholdDays already reflects any threshold-days annotation.
+type Input struct {
+	CronJob       *batchv1.CronJob
+	Jobs          []batchv1.Job
+	ThresholdDays int
+	Now           time.Time
+
+	DaysSinceSuccess int
+	TotalJobs        int
+	FailedJobs       int
+	Suspended        bool
+	HasRun           bool
+	LastSuccess      *time.Time
+	LastSchedule     *time.Time
+	ActiveJobs       int
+	EvidenceSource   string
+	Schedule         *ScheduleStats // nil when the schedule can't be parsed
+	// New is set for CronJobs that haven't run yet and were created less
+	// than their grace period ago, the longer of the threshold and two
+	// schedule periods, so they may simply not be due yet
+	New   bool
+	Hints Hints // zombie-hunter.io/* annotations
+}
+
+// Rule classifies a CronJob by returning signals. A rule that wants to flag
+// or excuse a CronJob sets Verdict on one of its signals.
+type Rule interface {
+	Name() string
+	Evaluate(in *Input) []Signal
+}
+
+// NewRule creates a Rule from a function
+func NewRule(name string, fn func(in *Input) []Signal) Rule {
+	return &funcRule{name: name, fn: fn}
+}
+
+type funcRule struct {
+	name string
+	fn   func(in *Input) []Signal
+}
+
+func (r *funcRule) Name() string {
+	return r.name
+}
+
+func (r *funcRule) Evaluate(in *Input) []Signal {
+	return r.fn(in)
+}
+
+// RuleSettings enables, disables or re-weights a registered rule. Nil fields
+// keep the current value.
+type RuleSettings struct {
+	Enabled *bool    `json:"enabled,omitempty"`
+	Weight  *float64 `json:"weight,omitempty"`
+}
+
+// Registry holds the rules a Detector evaluates, in registration order
+type Registry struct {
+	entries []*ruleEntry
+}
+
+type ruleEntry struct {
+	rule    Rule
+	enabled bool
+	weight  float64
+}
+
+// NewRegistry creates an empty rule registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry creates a registry holding the built-in rules
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, rule := range builtinRules() {
+		r.Register(rule)
+	}
+	return r
+}
+
+// Register adds a rule, enabled with weight 1
+func (r *Registry) Register(rule Rule) error {
+	if r.find(rule.Name()) != nil {
+		return fmt.Errorf("rule %q is already registered", rule.Name())
+	}
+	r.entries = append(r.entries, &ruleEntry{rule: rule, enabled: true, weight: 1})
+	return nil
+}
+
+// Configure applies settings keyed by rule name. Unknown names are an error
+// so typos in configuration don't go unnoticed.
+func (r *Registry) Configure(settings map[string]RuleSettings) error {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry := r.find(name)
+		if entry == nil {
+			return fmt.Errorf("unknown rule %q (known rules: %v)", name, r.Names())
+		}
+
+		s := settings[name]
+		if s.Weight != nil && *s.Weight < 0 {
+			return fmt.Errorf("rule %q: weight must not be negative, got %v", name, *s.Weight)
+		}
+		if s.Enabled != nil {
+			entry.enabled = *s.Enabled
+		}
+		if s.Weight != nil {
+			entry.weight = *s.Weight
+		}
+	}
+	return nil
+}
+
+// Names returns the names of all registered rules
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		names = append(names, e.rule.Name())
+	}
+	return names
+}
+
+// Evaluate runs every enabled rule and returns their weighted signals
+func (r *Registry) Evaluate(in *Input) []Signal {
+	var signals []Signal
+	for _, e := range r.entries {
+		if !e.enabled {
+			continue
+		}
+		for _, s := range e.rule.Evaluate(in) {
+			if s.Rule == "" {
+				s.Rule = e.rule.Name()
+			}
+			s.Weight = int(math.Round(float64(s.Weight) * e.weight))
+			signals = append(signals, s)
+		}
+	}
+	return signals
+}
+
+func (r *Registry) find(name string) *ruleEntry {
+	for _, e := range r.entries {
+		if e.rule.Name() == name {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package detector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistryConfigure(t *testing.T) {
+	enabled := false
+	weight := 0.5
+	negative := -1.0
+
+	tests := []struct {
+		name     string
+		settings map[string]RuleSettings
+		wantErr  string
+	}{
+		{
+			name:     "Disable a built-in rule",
+			settings: map[string]RuleSettings{RuleSuspended: {Enabled: &enabled}},
+		},
+		{
+			name:     "Re-weight a built-in rule",
+			settings: map[string]RuleSettings{RuleInactivity: {Weight: &weight}},
+		},
+		{
+			name:     "Unknown rule",
+			settings: map[string]RuleSettings{"inactivty": {Weight: &weight}},
+			wantErr:  `unknown rule "inactivty"`,
+		},
+		{
+			name:     "Negative weight",
+			settings: map[string]RuleSettings{RuleInactivity: {Weight: &negative}},
+			wantErr:  "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DefaultRegistry().Configure(tt.settings)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Configure() error = %v; want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Configure() error = %v; want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryDuplicateRule(t *testing.T) {
+	r := DefaultRegistry()
+	if err := r.Register(NewRule(RuleInactivity, func(in *Input) []Signal { return nil })); err == nil {
+		t.Errorf("Register() of a duplicate name succeeded; want error")
+	}
+}
+
+func TestDetectorCustomRules(t *testing.T) {
+	now := time.Now()
+
+	sandbox := NewRule("sandbox", func(in *Input) []Signal {
+		if !strings.HasPrefix(in.CronJob.Namespace, "sandbox-") || in.DaysSinceSuccess < 7 {
+			return nil
+		}
+		return []Signal{{Name: "sandbox", Weight: 90, Message: "sandbox jobs expire after 7 days", Verdict: VerdictZombie}}
+	})
+	argo := NewRule("argocd", func(in *Input) []Signal {
+		if _, ok := in.CronJob.Labels["argocd.argoproj.io/instance"]; !ok {
+			return nil
+		}
+		return []Signal{{Name: "argocd", Message: "managed by Argo CD", Verdict: VerdictIgnore}}
+	})
+
+	tests := []struct {
+		name               string
+		namespace          string
+		labels             map[string]string
+		lastSuccess        time.Time
+		expectedIsZombie   bool
+		expectedConfidence int
+	}{
+		{
+			name:               "Sandbox job quiet for 10 days",
+			namespace:          "sandbox-alice",
+			lastSuccess:        now.Add(-10 * 24 * time.Hour),
+			expectedIsZombie:   true,
+			expectedConfidence: 90,
+		},
+		{
+			name:             "Regular job quiet for 10 days",
+			namespace:        "default",
+			lastSuccess:      now.Add(-10 * 24 * time.Hour),
+			expectedIsZombie: false,
+		},
+		{
+			name:             "Argo CD job is ignored even when stale",
+			namespace:        "default",
+			labels:           map[string]string{"argocd.argoproj.io/instance": "billing"},
+			lastSuccess:      now.Add(-200 * 24 * time.Hour),
+			expectedIsZombie: false,
+		},
+	}
+
+	rules := DefaultRegistry()
+	rules.Register(sandbox)
+	rules.Register(argo)
+	d := NewDetector(rules, 30)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: tt.namespace, Labels: tt.labels},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *"},
+			}
+
+			zombie := d.Analyze(cronJob, []batchv1.Job{completedJob(tt.lastSuccess)})
+			if zombie.IsZombie != tt.expectedIsZombie {
+				t.Errorf("IsZombie = %v; want %v", zombie.IsZombie, tt.expectedIsZombie)
+			}
+			if zombie.IsZombie && zombie.Confidence != tt.expectedConfidence {
+				t.Errorf("Confidence = %d; want %d", zombie.Confidence, tt.expectedConfidence)
+			}
+		})
+	}
+}
+
+func TestDetectorRuleWeights(t *testing.T) {
+	now := time.Now()
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *", Suspend: boolPtr(true)},
+	}
+	jobs := []batchv1.Job{completedJob(now.Add(-100 * 24 * time.Hour))}
+
+	disabled := false
+	rules := DefaultRegistry()
+	if err := rules.Configure(map[string]RuleSettings{RuleSuspended: {Enabled: &disabled}}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+
+	zombie := NewDetector(rules, 30).Analyze(cronJob, jobs)
+	if zombie.Confidence != 85 {
+		t.Errorf("Confidence with suspended rule disabled = %d; want 85", zombie.Confidence)
+	}
+
+	half := 0.5
+	rules = DefaultRegistry()
+	if err := rules.Configure(map[string]RuleSettings{RuleSuspended: {Weight: &half}}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+
+	zombie = NewDetector(rules, 30).Analyze(cronJob, jobs)
+	if zombie.Confidence != 52 {
+		t.Errorf("Confidence with suspended rule at half weight = %d; want 52", zombie.Confidence)
+	}
+}
@@ -0,0 +1,123 @@
+package detector
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// MissedRatioThreshold is the share of expected runs that must have been
+// missed before a schedule-aware check flags a CronJob
+const MissedRatioThreshold = 0.8
+
+// maxCountedRuns caps how many schedule activations are walked one by one;
+// beyond it the remaining runs are estimated from the schedule period
+const maxCountedRuns = 10000
+
+// ScheduleStats describes how a CronJob's schedule lines up with its history
+type ScheduleStats struct {
+	ExpectedRuns     int
+	MissedRuns       int
+	NextScheduledRun *time.Time
+}
+
+// MissedRatio returns the share of expected runs that were missed (0-1)
+func (s ScheduleStats) MissedRatio() float64 {
+	if s.ExpectedRuns == 0 {
+		return 0
+	}
+	missed := s.MissedRuns
+	if missed > s.ExpectedRuns {
+		missed = s.ExpectedRuns
+	}
+	return float64(missed) / float64(s.ExpectedRuns)
+}
+
+// ParseSchedule parses a CronJob schedule, honouring Spec.TimeZone and the
+// @hourly/@daily/@weekly style macros the same way the CronJob controller does
+func ParseSchedule(cronJob *batchv1.CronJob) (cron.Schedule, error) {
+	spec := cronJob.Spec.Schedule
+	if cronJob.Spec.TimeZone != nil && *cronJob.Spec.TimeZone != "" {
+		if strings.Contains(spec, "TZ=") {
+			return nil, fmt.Errorf("schedule %q sets a time zone and spec.timeZone is also set", spec)
+		}
+		spec = "CRON_TZ=" + *cronJob.Spec.TimeZone + " " + spec
+	}
+
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", cronJob.Spec.Schedule, err)
+	}
+	return sched, nil
+}
+
+// AnalyzeSchedule counts the runs expected in the evaluation window and the
+// runs missed since the last success. The window covers thresholdDays, is
+// stretched to hold at least one run for infrequent schedules, and never
+// starts before the CronJob was created.
+func AnalyzeSchedule(sched cron.Schedule, created time.Time, lastSuccess *time.Time, thresholdDays int, now time.Time) ScheduleStats {
+	windowStart := now.AddDate(0, 0, -thresholdDays)
+	if period := schedulePeriod(sched, now); period > 0 && now.Sub(windowStart) < period {
+		windowStart = now.Add(-period)
+	}
+	if !created.IsZero() && created.After(windowStart) {
+		windowStart = created
+	}
+
+	missedFrom := windowStart
+	if lastSuccess != nil && lastSuccess.After(created) {
+		missedFrom = *lastSuccess
+	}
+
+	next := sched.Next(now)
+	return ScheduleStats{
+		ExpectedRuns:     countRuns(sched, windowStart, now),
+		MissedRuns:       countRuns(sched, missedFrom, now),
+		NextScheduledRun: &next,
+	}
+}
+
+// schedulePeriod estimates the time between two consecutive runs
+func schedulePeriod(sched cron.Schedule, from time.Time) time.Duration {
+	first := sched.Next(from)
+	if first.IsZero() {
+		return 0
+	}
+	second := sched.Next(first)
+	if second.IsZero() {
+		return 0
+	}
+	return second.Sub(first)
+}
+
+// CountRuns counts schedule activations in the interval (from, to]. Past
+// maxCountedRuns the rest are estimated from the schedule period.
+func CountRuns(sched cron.Schedule, from, to time.Time) int {
+	return countRuns(sched, from, to)
+}
+
+// countRuns counts schedule activations in the interval (from, to]
+func countRuns(sched cron.Schedule, from, to time.Time) int {
+	count := 0
+	t := sched.Next(from)
+	for !t.IsZero() && !t.After(to) {
+		count++
+		if count == maxCountedRuns {
+			if period := schedulePeriod(sched, t); period > 0 {
+				count += int(to.Sub(t) / period)
+			}
+			break
+		}
+		t = sched.Next(t)
+	}
+	return count
+}
+
+// missedRunConfidence scores a CronJob from the share of runs it missed.
+// It tops out at 85% so that long inactivity still ranks highest.
+func missedRunConfidence(stats ScheduleStats) int {
+	return int(85 * stats.MissedRatio())
+}
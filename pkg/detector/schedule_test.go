@@ -0,0 +1,226 @@
+package detector
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone *string
+		wantErr  bool
+	}{
+		{name: "Standard five-field schedule", schedule: "*/5 * * * *"},
+		{name: "Hourly macro", schedule: "@hourly"},
+		{name: "Weekly macro", schedule: "@weekly"},
+		{name: "Time zone from spec", schedule: "0 3 * * *", timeZone: stringPtr("Europe/Berlin")},
+		{name: "Unknown time zone", schedule: "0 3 * * *", timeZone: stringPtr("Mars/Olympus"), wantErr: true},
+		{name: "Time zone set twice", schedule: "CRON_TZ=UTC 0 3 * * *", timeZone: stringPtr("UTC"), wantErr: true},
+		{name: "Garbage schedule", schedule: "every tuesday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: tt.schedule, TimeZone: tt.timeZone}}
+			_, err := ParseSchedule(cronJob)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSchedule(%q) error = %v; wantErr %v", tt.schedule, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSchedule(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		schedule       string
+		created        time.Time
+		lastSuccess    *time.Time
+		thresholdDays  int
+		expectedRuns   int
+		missedRuns     int
+		expectedNextAt time.Time
+	}{
+		{
+			name:           "Daily job that succeeded this morning",
+			schedule:       "0 6 * * *",
+			lastSuccess:    timePtr(time.Date(2025, 3, 15, 6, 5, 0, 0, time.UTC)),
+			thresholdDays:  30,
+			expectedRuns:   30,
+			missedRuns:     0,
+			expectedNextAt: time.Date(2025, 3, 16, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Yearly job stretches the window to one run",
+			schedule:       "0 0 1 1 *",
+			lastSuccess:    timePtr(time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)),
+			thresholdDays:  30,
+			expectedRuns:   1,
+			missedRuns:     0,
+			expectedNextAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Window never starts before creation",
+			schedule:       "@daily",
+			created:        time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+			thresholdDays:  30,
+			expectedRuns:   5,
+			missedRuns:     5,
+			expectedNextAt: time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Every-minute job is estimated past the walk limit",
+			schedule:       "* * * * *",
+			lastSuccess:    timePtr(now.AddDate(0, 0, -20)),
+			thresholdDays:  30,
+			expectedRuns:   30 * 24 * 60,
+			missedRuns:     20 * 24 * 60,
+			expectedNextAt: now.Add(time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := ParseSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: tt.schedule}})
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) failed: %v", tt.schedule, err)
+			}
+
+			stats := AnalyzeSchedule(sched, tt.created, tt.lastSuccess, tt.thresholdDays, now)
+			if stats.ExpectedRuns != tt.expectedRuns {
+				t.Errorf("ExpectedRuns = %d; want %d", stats.ExpectedRuns, tt.expectedRuns)
+			}
+			if stats.MissedRuns != tt.missedRuns {
+				t.Errorf("MissedRuns = %d; want %d", stats.MissedRuns, tt.missedRuns)
+			}
+			if stats.NextScheduledRun == nil || !stats.NextScheduledRun.Equal(tt.expectedNextAt) {
+				t.Errorf("NextScheduledRun = %v; want %v", stats.NextScheduledRun, tt.expectedNextAt)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCronJobSchedule(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		schedule         string
+		lastSuccess      time.Time
+		expectedIsZombie bool
+	}{
+		{
+			name:             "Yearly job quiet for 40 days is healthy",
+			schedule:         "0 0 1 1 *",
+			lastSuccess:      now.AddDate(0, 0, -40),
+			expectedIsZombie: false,
+		},
+		{
+			name:             "Five-minute job broken for 29 days is a zombie",
+			schedule:         "*/5 * * * *",
+			lastSuccess:      now.AddDate(0, 0, -29),
+			expectedIsZombie: true,
+		},
+		{
+			name:             "Daily job broken for 10 days is not yet a zombie",
+			schedule:         "@daily",
+			lastSuccess:      now.AddDate(0, 0, -10),
+			expectedIsZombie: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+				Spec:       batchv1.CronJobSpec{Schedule: tt.schedule},
+			}
+			jobs := []batchv1.Job{
+				{
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:               batchv1.JobComplete,
+								Status:             v1.ConditionTrue,
+								LastTransitionTime: metav1.NewTime(tt.lastSuccess),
+							},
+						},
+					},
+				},
+			}
+
+			zombie := AnalyzeCronJob(cronJob, jobs, 30)
+			if zombie.IsZombie != tt.expectedIsZombie {
+				t.Errorf("IsZombie = %v; want %v (missed %d of %d runs)",
+					zombie.IsZombie, tt.expectedIsZombie, zombie.MissedRuns, zombie.ExpectedRuns)
+			}
+			if zombie.NextScheduledRun == nil {
+				t.Errorf("NextScheduledRun is nil")
+			}
+		})
+	}
+}
+
+func TestAnalyzeCronJobGracePeriod(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		schedule         string
+		created          time.Time
+		expectedIsZombie bool
+		expectedSignals  bool
+	}{
+		{
+			name:     "Every-minute job created an hour ago is not judged yet",
+			schedule: "* * * * *",
+			created:  now.Add(-time.Hour),
+		},
+		{
+			name:             "Every-minute job that never ran in 31 days is a zombie",
+			schedule:         "* * * * *",
+			created:          now.AddDate(0, 0, -31),
+			expectedIsZombie: true,
+			expectedSignals:  true,
+		},
+		{
+			name:     "Yearly job gets two periods, not the 30-day threshold",
+			schedule: "0 0 1 1 *",
+			created:  now.AddDate(0, -18, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default", CreationTimestamp: metav1.NewTime(tt.created)},
+				Spec:       batchv1.CronJobSpec{Schedule: tt.schedule},
+			}
+
+			zombie := AnalyzeCronJob(cronJob, nil, 30)
+			if zombie.IsZombie != tt.expectedIsZombie {
+				t.Errorf("IsZombie = %v; want %v (confidence %d, signals %v)",
+					zombie.IsZombie, tt.expectedIsZombie, zombie.Confidence, zombie.Signals)
+			}
+			if got := len(zombie.Signals) > 0; got != tt.expectedSignals {
+				t.Errorf("Signals = %v; want any: %v", zombie.Signals, tt.expectedSignals)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,57 @@
+package detector
+
+// Signal names produced by the detector
+const (
+	SignalSuspended    = "suspended"
+	SignalNeverRan     = "never-ran"
+	SignalAllFailed    = "all-failed"
+	SignalFailedJobs   = "failed-jobs"
+	SignalInactivity   = "inactivity"
+	SignalMissedRuns   = "missed-runs"
+	SignalEvidence     = "evidence"
+	SignalActive       = "active"
+	SignalAcknowledged = "acknowledged"
+	SignalAnnotation   = "annotation"
+	SignalQuarantined  = "quarantined"
+)
+
+// Verdicts a signal can carry
+const (
+	VerdictZombie = "zombie" // the CronJob is a zombie
+	VerdictIgnore = "ignore" // never report the CronJob, whatever other rules say
+)
+
+// Signal is one piece of evidence behind a verdict. Weight is the confidence
+// (0-99) the signal supports on its own; negative weights lower the score and
+// zero-weight signals are informational only.
+type Signal struct {
+	Name     string
+	Rule     string
+	Weight   int
+	Observed string
+	Message  string
+	Verdict  string
+}
+
+// Score combines signals into a confidence score (0-99%): the strongest
+// positive signal, lowered by any negative ones
+func Score(signals []Signal) int {
+	best, penalty := 0, 0
+	for _, s := range signals {
+		if s.Weight > best {
+			best = s.Weight
+		}
+		if s.Weight < 0 {
+			penalty += s.Weight
+		}
+	}
+
+	score := best + penalty
+	if score < 0 {
+		return 0
+	}
+	if score > 99 {
+		return 99
+	}
+	return score
+}
@@ -0,0 +1,81 @@
+package detector
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		signals  []Signal
+		expected int
+	}{
+		{
+			name:     "No signals",
+			signals:  nil,
+			expected: 0,
+		},
+		{
+			name:     "Strongest signal wins",
+			signals:  []Signal{{Weight: 60}, {Weight: 95}, {Weight: 0}},
+			expected: 95,
+		},
+		{
+			name:     "Negative signals lower the score",
+			signals:  []Signal{{Weight: 85}, {Weight: -65}},
+			expected: 20,
+		},
+		{
+			name:     "Score never drops below zero",
+			signals:  []Signal{{Weight: 40}, {Weight: -65}},
+			expected: 0,
+		},
+		{
+			name:     "Score is capped at 99",
+			signals:  []Signal{{Weight: 150}},
+			expected: 99,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Score(tt.signals); result != tt.expected {
+				t.Errorf("Score() = %d; want %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCronJobSignals(t *testing.T) {
+	now := time.Now()
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *", Suspend: boolPtr(true)},
+		Status:     batchv1.CronJobStatus{LastScheduleTime: timeRef(now.Add(-100 * 24 * time.Hour))},
+	}
+	jobs := []batchv1.Job{completedJob(now.Add(-100 * 24 * time.Hour))}
+
+	zombie := AnalyzeCronJob(cronJob, jobs, 30)
+
+	found := map[string]Signal{}
+	for _, s := range zombie.Signals {
+		found[s.Name] = s
+	}
+
+	for _, name := range []string{SignalSuspended, SignalInactivity, SignalMissedRuns} {
+		if _, ok := found[name]; !ok {
+			t.Errorf("missing %q signal in %+v", name, zombie.Signals)
+		}
+	}
+	if found[SignalInactivity].Observed != "100 days" {
+		t.Errorf("inactivity Observed = %q; want %q", found[SignalInactivity].Observed, "100 days")
+	}
+	if zombie.Confidence != Score(zombie.Signals) {
+		t.Errorf("Confidence = %d; want Score(Signals) = %d", zombie.Confidence, Score(zombie.Signals))
+	}
+}
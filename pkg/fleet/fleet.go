@@ -0,0 +1,118 @@
+package fleet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+)
+
+// DefaultConcurrency is how many clusters are scanned at once by default
+const DefaultConcurrency = 4
+
+// Cluster scan statuses
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// CurrentContext names a scan of the current kubeconfig context when the
+// client could not even be created to tell its real name
+const CurrentContext = "(current context)"
+
+// ScanFunc scans one kubeconfig context ("" for the current one) and
+// returns the cluster's name, its zombies and what was read
+type ScanFunc func(ctx context.Context, kubeContext string) (string, []detector.Zombie, k8s.ScanStats, error)
+
+// Cluster is the outcome of scanning one cluster
+type Cluster struct {
+	Name     string
+	Status   string
+	Duration time.Duration
+	Err      error
+	Zombies  []detector.Zombie
+	Scanned  k8s.ScanStats
+}
+
+// Scan runs scan for every context with at most concurrency scans in
+// flight. A failing cluster is recorded with StatusFailed and does not stop
+// the others. Results are in the order of contexts.
+func Scan(ctx context.Context, contexts []string, concurrency int, scan ScanFunc) []Cluster {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	clusters := make([]Cluster, len(contexts))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, kubeContext := range contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			clusters[i] = scanOne(ctx, kubeContext, scan)
+		}()
+	}
+
+	wg.Wait()
+	return clusters
+}
+
+func scanOne(ctx context.Context, kubeContext string, scan ScanFunc) Cluster {
+	start := time.Now()
+	name, zombies, stats, err := scan(ctx, kubeContext)
+
+	c := Cluster{
+		Name:     name,
+		Status:   StatusOK,
+		Duration: time.Since(start),
+		Zombies:  zombies,
+		Scanned:  stats,
+	}
+	if c.Name == "" {
+		c.Name = kubeContext
+	}
+	if c.Name == "" {
+		c.Name = CurrentContext
+	}
+	if err != nil {
+		c.Status = StatusFailed
+		c.Err = err
+	}
+	return c
+}
+
+// Zombies returns the results of every cluster, cluster by cluster
+func Zombies(clusters []Cluster) []detector.Zombie {
+	var zombies []detector.Zombie
+	for _, c := range clusters {
+		zombies = append(zombies, c.Zombies...)
+	}
+	return zombies
+}
+
+// Scanned returns the combined counts of every cluster
+func Scanned(clusters []Cluster) k8s.ScanStats {
+	var total k8s.ScanStats
+	for _, c := range clusters {
+		total.Add(c.Scanned)
+	}
+	return total
+}
+
+// Failed returns the clusters whose scan failed
+func Failed(clusters []Cluster) []Cluster {
+	var failed []Cluster
+	for _, c := range clusters {
+		if c.Status == StatusFailed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
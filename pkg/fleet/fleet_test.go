@@ -0,0 +1,73 @@
+package fleet
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+)
+
+func TestScan(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	scan := func(ctx context.Context, kubeContext string) (string, []detector.Zombie, k8s.ScanStats, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if kubeContext == "broken" {
+			return "", nil, k8s.ScanStats{}, errors.New("connection refused")
+		}
+		zombie := detector.Zombie{Cluster: kubeContext, Name: "job"}
+		return kubeContext, []detector.Zombie{zombie}, k8s.ScanStats{CronJobs: 3}, nil
+	}
+
+	contexts := []string{"a", "b", "broken", "c", "d", "e"}
+	clusters := Scan(context.Background(), contexts, 2, scan)
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("%d scans ran at once; want at most 2", got)
+	}
+
+	if len(clusters) != len(contexts) {
+		t.Fatalf("Scan() returned %d clusters; want %d", len(clusters), len(contexts))
+	}
+	for i, c := range clusters {
+		if c.Name != contexts[i] {
+			t.Errorf("clusters[%d].Name = %q; want %q", i, c.Name, contexts[i])
+		}
+	}
+
+	failed := Failed(clusters)
+	if len(failed) != 1 || failed[0].Name != "broken" || failed[0].Err == nil {
+		t.Errorf("Failed() = %+v; want only the broken cluster with its error", failed)
+	}
+
+	if got := len(Zombies(clusters)); got != 5 {
+		t.Errorf("Zombies() has %d results; want 5", got)
+	}
+	if got := Scanned(clusters).CronJobs; got != 15 {
+		t.Errorf("Scanned().CronJobs = %d; want 15", got)
+	}
+}
+
+func TestScanNamesCurrentContext(t *testing.T) {
+	scan := func(ctx context.Context, kubeContext string) (string, []detector.Zombie, k8s.ScanStats, error) {
+		return "", nil, k8s.ScanStats{}, errors.New("no kubeconfig")
+	}
+
+	clusters := Scan(context.Background(), []string{""}, DefaultConcurrency, scan)
+	if clusters[0].Name != CurrentContext {
+		t.Errorf("Name = %q; want %q", clusters[0].Name, CurrentContext)
+	}
+}
@@ -2,9 +2,8 @@ package k8s
 
 import (
 	"context"
-	"os"
-	"path/filepath"
-	"time"
+	"maps"
+	"slices"
 
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,12 +13,32 @@ import (
 )
 
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
+	config    *rest.Config
+	opts      Options
+	cluster   string
+}
+
+// InCluster is the cluster name used when running inside a Pod
+const InCluster = "in-cluster"
+
+// Options tune how the client connects and lists objects
+type Options struct {
+	// Kubeconfig is an explicit kubeconfig file. When empty the standard
+	// rules apply: $KUBECONFIG (a path list, merged) or ~/.kube/config.
+	Kubeconfig string
+	// Context is the kubeconfig context to use; empty means the current one
+	Context string
+	// PageSize is how many objects each List call returns; 0 means DefaultPageSize
+	PageSize int64
+	// FullJobs keeps complete Job objects instead of trimming them to the
+	// fields the detector reads, for policies that inspect Job specs
+	FullJobs bool
 }
 
 // NewClient creates a new Kubernetes client
-func NewClient() (*Client, error) {
-	config, err := getConfig()
+func NewClient(opts Options) (*Client, error) {
+	config, cluster, err := getConfig(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -29,136 +48,73 @@ func NewClient() (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{clientset: clientset}, nil
+	return &Client{clientset: clientset, config: config, opts: opts, cluster: cluster}, nil
 }
 
-// getConfig returns K8s config
-func getConfig() (*rest.Config, error) {
-	// Try in-cluster config first
-	config, err := rest.InClusterConfig()
-	if err == nil {
-		return config, nil
-	}
-
-	// Fall back to kubeconfig
-	kubeconfig := os.Getenv("KUBECONFIG")
-	if kubeconfig == "" {
-		home, _ := os.UserHomeDir()
-		kubeconfig = filepath.Join(home, ".kube", "config")
-	}
-
-	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+// Cluster returns the name of the cluster the client talks to: the
+// kubeconfig context, or InCluster
+func (c *Client) Cluster() string {
+	return c.cluster
 }
 
-// GetCronJobs returns all CronJobs
-func (c *Client) GetCronJobs(ctx context.Context, namespace string) ([]CronJobInfo, error) {
-	var ns string
-	if namespace == "" {
-		ns = metav1.NamespaceAll
-	} else {
-		ns = namespace
-	}
-
-	cronJobs, err := c.clientset.BatchV1().CronJobs(ns).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	var result []CronJobInfo
-	for _, cj := range cronJobs.Items {
-		info := CronJobInfo{
-			Name:      cj.Name,
-			Namespace: cj.Namespace,
-			Schedule:  cj.Spec.Schedule,
-			Suspended: false,
-		}
-
-		if cj.Spec.Suspend != nil {
-			info.Suspended = *cj.Spec.Suspend
-		}
-
-		result = append(result, info)
-	}
+// Clientset returns the underlying Kubernetes clientset, for callers that
+// change objects
+func (c *Client) Clientset() kubernetes.Interface {
+	return c.clientset
+}
 
-	return result, nil
+// Config returns the REST config the client was created from, for clients
+// of other API groups
+func (c *Client) Config() *rest.Config {
+	return c.config
 }
 
-// GetJobsForCronJob returns all Jobs for a CronJob
-func (c *Client) GetJobsForCronJob(ctx context.Context, namespace, cronJobName string) ([]JobInfo, error) {
-	jobs, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: "cronjob=" + cronJobName,
-	})
+// Contexts returns the context names in the merged kubeconfig, sorted
+func Contexts(kubeconfig string) ([]string, error) {
+	raw, err := clientConfig(kubeconfig, "").RawConfig()
 	if err != nil {
 		return nil, err
 	}
-
-	var result []JobInfo
-	for _, job := range jobs.Items {
-		info := JobInfo{
-			Name:           job.Name,
-			Succeeded:      job.Status.Succeeded,
-			Failed:         job.Status.Failed,
-			CompletionTime: nil,
-		}
-
-		if job.Status.CompletionTime != nil {
-			t := job.Status.CompletionTime.Time
-			info.CompletionTime = &t
-		}
-
-		result = append(result, info)
-	}
-
-	return result, nil
+	return slices.Sorted(maps.Keys(raw.Contexts)), nil
 }
 
-// GetRawCronJobs returns raw Kubernetes CronJob objects
-func (c *Client) GetRawCronJobs(ctx context.Context, namespace string) (*batchv1.CronJobList, error) {
-	var ns string
-	if namespace == "" {
-		ns = metav1.NamespaceAll
-	} else {
-		ns = namespace
+// getConfig returns K8s config and the name of the cluster it points at
+func getConfig(opts Options) (*rest.Config, string, error) {
+	// Try in-cluster config first, unless a kubeconfig was asked for
+	if opts.Kubeconfig == "" && opts.Context == "" {
+		if config, err := rest.InClusterConfig(); err == nil {
+			return config, InCluster, nil
+		}
 	}
 
-	return c.clientset.BatchV1().CronJobs(ns).List(ctx, metav1.ListOptions{})
-}
-
-// GetRawJobsForCronJob returns raw Kubernetes Job objects for a CronJob
-func (c *Client) GetRawJobsForCronJob(ctx context.Context, namespace, cronJobName string) (*batchv1.JobList, error) {
-	// Get all jobs in namespace
-	allJobs, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	// Fall back to kubeconfig
+	cc := clientConfig(opts.Kubeconfig, opts.Context)
+	config, err := cc.ClientConfig()
 	if err != nil {
-		return nil, err
-	}
-
-	// Filter jobs that belong to this CronJob
-	filteredJobs := &batchv1.JobList{
-		Items: []batchv1.Job{},
+		return nil, "", err
 	}
 
-	for _, job := range allJobs.Items {
-		for _, owner := range job.OwnerReferences {
-			if owner.Kind == "CronJob" && owner.Name == cronJobName {
-				filteredJobs.Items = append(filteredJobs.Items, job)
-				break
-			}
+	cluster := opts.Context
+	if cluster == "" {
+		raw, err := cc.RawConfig()
+		if err != nil {
+			return nil, "", err
 		}
+		cluster = raw.CurrentContext
 	}
-
-	return filteredJobs, nil
+	return config, cluster, nil
 }
 
-type CronJobInfo struct {
-	Name      string
-	Namespace string
-	Schedule  string
-	Suspended bool
+// clientConfig loads kubeconfig the way kubectl does: an explicit file, else
+// every file in $KUBECONFIG merged, else ~/.kube/config
+func clientConfig(kubeconfig, context string) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
 }
 
-type JobInfo struct {
-	Name           string
-	Succeeded      int32
-	Failed         int32
-	CompletionTime *time.Time
+// GetRawCronJob returns a single raw Kubernetes CronJob object
+func (c *Client) GetRawCronJob(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	return c.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
 }
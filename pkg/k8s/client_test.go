@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: CONTEXT
+  cluster:
+    server: https://CONTEXT.example.com
+users:
+- name: CONTEXT
+  user:
+    token: secret
+contexts:
+- name: CONTEXT
+  context:
+    cluster: CONTEXT
+    user: CONTEXT
+current-context: CONTEXT
+`
+
+func TestContextsMergesKubeconfigList(t *testing.T) {
+	dir := t.TempDir()
+	prod := writeKubeconfig(t, dir, "prod")
+	staging := writeKubeconfig(t, dir, "staging")
+
+	t.Setenv("KUBECONFIG", prod+string(filepath.ListSeparator)+staging)
+
+	got, err := Contexts("")
+	if err != nil {
+		t.Fatalf("Contexts() failed: %v", err)
+	}
+	if want := []string{"prod", "staging"}; !slices.Equal(got, want) {
+		t.Errorf("Contexts() = %v; want %v", got, want)
+	}
+
+	got, err = Contexts(staging)
+	if err != nil {
+		t.Fatalf("Contexts(%q) failed: %v", staging, err)
+	}
+	if want := []string{"staging"}; !slices.Equal(got, want) {
+		t.Errorf("Contexts(%q) = %v; want %v", staging, got, want)
+	}
+}
+
+func TestGetConfigContext(t *testing.T) {
+	dir := t.TempDir()
+	prod := writeKubeconfig(t, dir, "prod")
+	staging := writeKubeconfig(t, dir, "staging")
+	t.Setenv("KUBECONFIG", prod+string(filepath.ListSeparator)+staging)
+	// Don't pick up in-cluster config when the tests run in a Pod
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	tests := []struct {
+		name            string
+		opts            Options
+		expectedCluster string
+		expectedHost    string
+	}{
+		{
+			name:            "Current context of the first file",
+			opts:            Options{},
+			expectedCluster: "prod",
+			expectedHost:    "https://prod.example.com",
+		},
+		{
+			name:            "Explicit context",
+			opts:            Options{Context: "staging"},
+			expectedCluster: "staging",
+			expectedHost:    "https://staging.example.com",
+		},
+		{
+			name:            "Explicit kubeconfig",
+			opts:            Options{Kubeconfig: staging},
+			expectedCluster: "staging",
+			expectedHost:    "https://staging.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, cluster, err := getConfig(tt.opts)
+			if err != nil {
+				t.Fatalf("getConfig() failed: %v", err)
+			}
+			if cluster != tt.expectedCluster {
+				t.Errorf("cluster = %q; want %q", cluster, tt.expectedCluster)
+			}
+			if config.Host != tt.expectedHost {
+				t.Errorf("Host = %q; want %q", config.Host, tt.expectedHost)
+			}
+		})
+	}
+}
+
+func writeKubeconfig(t *testing.T, dir, context string) string {
+	t.Helper()
+	path := filepath.Join(dir, context+".yaml")
+	data := strings.ReplaceAll(kubeconfigTemplate, "CONTEXT", context)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,160 @@
+package k8s
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Filter selects which CronJobs a scan covers
+type Filter struct {
+	// IncludeNamespaces are namespace names or globs such as "team-*";
+	// empty means all namespaces
+	IncludeNamespaces []string
+	// ExcludeNamespaces are removed after IncludeNamespaces is applied
+	ExcludeNamespaces []string
+	// NamespaceSelector is a label selector on Namespace objects,
+	// e.g. "team=payments"
+	NamespaceSelector string
+	// LabelSelector is a label selector on the CronJobs themselves
+	LabelSelector string
+}
+
+// IsPattern reports whether a namespace entry is a glob rather than a name
+func IsPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[\\")
+}
+
+// MatchesNamespace reports whether a namespace passes the include/exclude
+// lists. It does not check NamespaceSelector.
+func (f Filter) MatchesNamespace(namespace string) bool {
+	if len(f.IncludeNamespaces) > 0 && !matchAny(f.IncludeNamespaces, namespace) {
+		return false
+	}
+	return !matchAny(f.ExcludeNamespaces, namespace)
+}
+
+// ListCronJobs returns the CronJobs selected by the filter. Selection happens
+// server-side where the API allows it: literal namespaces are listed one by
+// one, literal exclusions become field selectors and the label selector is
+// passed through. Globs and the namespace selector are matched against the
+// Namespace list, and the matching namespaces are then listed one by one.
+func (c *Client) ListCronJobs(ctx context.Context, filter Filter) ([]batchv1.CronJob, error) {
+	stats := &ScanStats{}
+	namespaces, err := c.resolveNamespaces(ctx, filter, stats)
+	if err != nil {
+		return nil, err
+	}
+	return c.listCronJobs(ctx, namespaces, filter, stats)
+}
+
+// listCronJobs lists CronJobs in the given namespaces, or cluster-wide when
+// namespaces is nil
+func (c *Client) listCronJobs(ctx context.Context, namespaces []string, filter Filter, stats *ScanStats) ([]batchv1.CronJob, error) {
+	var result []batchv1.CronJob
+	keep := func(obj runtime.Object) error {
+		cj := obj.(*batchv1.CronJob)
+		if filter.MatchesNamespace(cj.Namespace) {
+			result = append(result, *cj)
+		}
+		return nil
+	}
+
+	if namespaces == nil {
+		// Cluster-wide list
+		opts := metav1.ListOptions{
+			LabelSelector: filter.LabelSelector,
+			FieldSelector: excludeFieldSelector(filter.ExcludeNamespaces),
+		}
+		if err := c.each(ctx, c.cronJobPages(metav1.NamespaceAll), opts, stats, keep); err != nil {
+			return nil, err
+		}
+		stats.CronJobs = len(result)
+		return result, nil
+	}
+
+	for _, ns := range namespaces {
+		opts := metav1.ListOptions{LabelSelector: filter.LabelSelector}
+		if err := c.each(ctx, c.cronJobPages(ns), opts, stats, keep); err != nil {
+			return nil, err
+		}
+	}
+	stats.CronJobs = len(result)
+	return result, nil
+}
+
+// resolveNamespaces returns the namespaces to list one by one, or nil when a
+// single cluster-wide list is cheaper
+func (c *Client) resolveNamespaces(ctx context.Context, filter Filter, stats *ScanStats) ([]string, error) {
+	literal := true
+	for _, ns := range filter.IncludeNamespaces {
+		if IsPattern(ns) {
+			literal = false
+		}
+	}
+
+	if filter.NamespaceSelector == "" {
+		if len(filter.IncludeNamespaces) == 0 {
+			return nil, nil
+		}
+		if literal {
+			var namespaces []string
+			for _, ns := range filter.IncludeNamespaces {
+				if filter.MatchesNamespace(ns) {
+					namespaces = append(namespaces, ns)
+				}
+			}
+			return nonNil(namespaces), nil
+		}
+	}
+
+	var namespaces []string
+	err := c.each(ctx, c.namespacePages(), metav1.ListOptions{LabelSelector: filter.NamespaceSelector}, stats, func(obj runtime.Object) error {
+		if ns := obj.(*corev1.Namespace); filter.MatchesNamespace(ns.Name) {
+			namespaces = append(namespaces, ns.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nonNil(namespaces), nil
+}
+
+// excludeFieldSelector turns literal namespace exclusions into a field
+// selector; globs are applied client-side
+func excludeFieldSelector(exclude []string) string {
+	var selectors []fields.Selector
+	for _, ns := range exclude {
+		if !IsPattern(ns) {
+			selectors = append(selectors, fields.OneTermNotEqualSelector("metadata.namespace", ns))
+		}
+	}
+	if len(selectors) == 0 {
+		return ""
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+func matchAny(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, s); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// nonNil distinguishes "no namespaces matched" from "list cluster-wide"
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMatchesNamespace(t *testing.T) {
+	f := Filter{IncludeNamespaces: []string{"team-*", "default"}, ExcludeNamespaces: []string{"team-legacy"}}
+
+	tests := map[string]bool{
+		"default":     true,
+		"team-a":      true,
+		"team-legacy": false,
+		"kube-system": false,
+	}
+	for ns, want := range tests {
+		if got := f.MatchesNamespace(ns); got != want {
+			t.Errorf("MatchesNamespace(%q) = %v; want %v", ns, got, want)
+		}
+	}
+}
+
+func TestListCronJobs(t *testing.T) {
+	objects := []runtime.Object{
+		namespace("team-a", map[string]string{"team": "payments"}),
+		namespace("team-b", map[string]string{"team": "search"}),
+		namespace("kube-system", nil),
+		cronJob("team-a", "billing", map[string]string{"app": "billing"}),
+		cronJob("team-a", "report", nil),
+		cronJob("team-b", "indexer", map[string]string{"app": "search"}),
+		cronJob("kube-system", "cleanup", nil),
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+		requests []string
+	}{
+		{
+			name:     "Everything",
+			filter:   Filter{},
+			expected: []string{"kube-system/cleanup", "team-a/billing", "team-a/report", "team-b/indexer"},
+			requests: []string{"list cronjobs"},
+		},
+		{
+			name:     "Literal namespaces",
+			filter:   Filter{IncludeNamespaces: []string{"team-a", "team-b"}},
+			expected: []string{"team-a/billing", "team-a/report", "team-b/indexer"},
+			requests: []string{"list cronjobs team-a", "list cronjobs team-b"},
+		},
+		{
+			name:     "Glob include",
+			filter:   Filter{IncludeNamespaces: []string{"team-*"}},
+			expected: []string{"team-a/billing", "team-a/report", "team-b/indexer"},
+			requests: []string{"list namespaces", "list cronjobs team-a", "list cronjobs team-b"},
+		},
+		{
+			name:     "Glob exclude",
+			filter:   Filter{ExcludeNamespaces: []string{"kube-*"}},
+			expected: []string{"team-a/billing", "team-a/report", "team-b/indexer"},
+			requests: []string{"list cronjobs"},
+		},
+		{
+			name:     "Literal exclude",
+			filter:   Filter{ExcludeNamespaces: []string{"team-a"}},
+			expected: []string{"kube-system/cleanup", "team-b/indexer"},
+			requests: []string{"list cronjobs"},
+		},
+		{
+			name:     "Namespace label selector",
+			filter:   Filter{NamespaceSelector: "team=payments"},
+			expected: []string{"team-a/billing", "team-a/report"},
+			requests: []string{"list namespaces", "list cronjobs team-a"},
+		},
+		{
+			name:     "CronJob label selector",
+			filter:   Filter{LabelSelector: "app"},
+			expected: []string{"team-a/billing", "team-b/indexer"},
+			requests: []string{"list cronjobs"},
+		},
+		{
+			name:     "Nothing matches",
+			filter:   Filter{IncludeNamespaces: []string{"team-a"}, ExcludeNamespaces: []string{"team-*"}},
+			expected: nil,
+			requests: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(objects...)
+			c := &Client{clientset: clientset}
+
+			cronJobs, err := c.ListCronJobs(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("ListCronJobs() failed: %v", err)
+			}
+
+			var got []string
+			for _, cj := range cronJobs {
+				got = append(got, cj.Namespace+"/"+cj.Name)
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ListCronJobs() = %v; want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("ListCronJobs() = %v; want %v", got, tt.expected)
+				}
+			}
+
+			var requests []string
+			for _, a := range clientset.Actions() {
+				requests = append(requests, strings.TrimSpace(a.GetVerb()+" "+a.GetResource().Resource+" "+a.GetNamespace()))
+			}
+			if !slices.Equal(requests, tt.requests) {
+				t.Errorf("requests = %q; want %q", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func cronJob(namespace, name string, labels map[string]string) *batchv1.CronJob {
+	return &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+}
@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Tools that manage objects on behalf of a source outside the cluster
+const (
+	ManagedByHelm   = "Helm"
+	ManagedByArgoCD = "Argo CD"
+	ManagedByFlux   = "Flux"
+)
+
+// Labels and annotations GitOps tools and Helm put on the objects they own
+const (
+	LabelManagedBy                  = "app.kubernetes.io/managed-by"
+	LabelInstance                   = "app.kubernetes.io/instance"
+	AnnotationHelmRelease           = "meta.helm.sh/release-name"
+	AnnotationHelmReleaseNamespace  = "meta.helm.sh/release-namespace"
+	AnnotationArgoTrackingID        = "argocd.argoproj.io/tracking-id"
+	LabelFluxKustomizationName      = "kustomize.toolkit.fluxcd.io/name"
+	LabelFluxKustomizationNamespace = "kustomize.toolkit.fluxcd.io/namespace"
+	LabelFluxHelmReleaseName        = "helm.toolkit.fluxcd.io/name"
+	LabelFluxHelmReleaseNamespace   = "helm.toolkit.fluxcd.io/namespace"
+)
+
+// managerSources maps the field managers of well-known tools to the kind
+// of source they apply objects from
+var managerSources = map[string]detector.GitOpsSource{
+	"helm":                          {Tool: ManagedByHelm, Kind: "release"},
+	"argocd-controller":             {Tool: ManagedByArgoCD, Kind: "Application"},
+	"argocd-application-controller": {Tool: ManagedByArgoCD, Kind: "Application"},
+	"kustomize-controller":          {Tool: ManagedByFlux, Kind: "Kustomization"},
+	"helm-controller":               {Tool: ManagedByFlux, Kind: "HelmRelease"},
+}
+
+// ProvenanceOf reads who created and last changed an object from its
+// managedFields, and which GitOps tool or Helm release owns it from
+// ownership labels and annotations, falling back to the field managers.
+// Entries for the status subresource and for the ignored managers, such as
+// zombie-hunter's own marks, are skipped.
+//
+// managedFields don't record creation as such: CreatedBy is the manager
+// with the oldest entry, which is the creator unless it has since changed
+// the object or given up all its fields.
+func ProvenanceOf(obj metav1.Object, ignoreManagers ...string) detector.Provenance {
+	var p detector.Provenance
+	var created, modified time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "" || entry.Time == nil || slices.Contains(ignoreManagers, entry.Manager) {
+			continue
+		}
+		t := entry.Time.Time
+		if p.CreatedBy == "" || t.Before(created) {
+			p.CreatedBy, created = entry.Manager, t
+		}
+		if p.LastModifiedBy == "" || t.After(modified) {
+			p.LastModifiedBy, modified = entry.Manager, t
+		}
+	}
+	if p.LastModifiedBy != "" {
+		p.LastModifiedAt = &modified
+	}
+
+	p.GitOps = GitOpsSourceOf(obj)
+	if p.GitOps != nil {
+		p.ManagedBy = p.GitOps.Tool
+	} else {
+		p.ManagedBy = obj.GetLabels()[LabelManagedBy]
+	}
+	return p
+}
+
+// GitOpsSourceOf returns the Argo CD Application, Flux Kustomization or
+// HelmRelease, or Helm release an object is deployed from, with the
+// suggested remediation, or nil when there is none.
+//
+// Flux is checked first because its helm-controller also sets Helm's
+// annotations. The app.kubernetes.io/instance label counts as Argo CD's
+// label-based tracking only on objects Helm didn't install, since Helm
+// charts set it to the release name.
+func GitOpsSourceOf(obj metav1.Object) *detector.GitOpsSource {
+	labels, annotations := obj.GetLabels(), obj.GetAnnotations()
+
+	var src *detector.GitOpsSource
+	switch {
+	case labels[LabelFluxKustomizationName] != "":
+		src = &detector.GitOpsSource{Tool: ManagedByFlux, Kind: "Kustomization",
+			Namespace: labels[LabelFluxKustomizationNamespace], Name: labels[LabelFluxKustomizationName]}
+	case labels[LabelFluxHelmReleaseName] != "":
+		src = &detector.GitOpsSource{Tool: ManagedByFlux, Kind: "HelmRelease",
+			Namespace: labels[LabelFluxHelmReleaseNamespace], Name: labels[LabelFluxHelmReleaseName]}
+	case annotations[AnnotationArgoTrackingID] != "":
+		// <application>:<group>/<kind>:<namespace>/<name>
+		app, _, _ := strings.Cut(annotations[AnnotationArgoTrackingID], ":")
+		src = argoApplication(app)
+	case annotations[AnnotationHelmRelease] != "":
+		src = &detector.GitOpsSource{Tool: ManagedByHelm, Kind: "release",
+			Namespace: annotations[AnnotationHelmReleaseNamespace], Name: annotations[AnnotationHelmRelease]}
+	case labels[LabelInstance] != "":
+		src = argoApplication(labels[LabelInstance])
+	default:
+		for _, entry := range obj.GetManagedFields() {
+			if known, ok := managerSources[entry.Manager]; ok {
+				src = &known
+				break
+			}
+		}
+	}
+	if src == nil {
+		return nil
+	}
+	src.Action = remediation(*src)
+	return src
+}
+
+// argoApplication parses an Argo CD application reference, which is
+// "<namespace>_<name>" for applications outside Argo CD's own namespace
+func argoApplication(ref string) *detector.GitOpsSource {
+	src := &detector.GitOpsSource{Tool: ManagedByArgoCD, Kind: "Application", Name: ref}
+	if ns, name, ok := strings.Cut(ref, "_"); ok {
+		src.Namespace, src.Name = ns, name
+	}
+	return src
+}
+
+// remediation suggests how to retire a CronJob at its source
+func remediation(src detector.GitOpsSource) string {
+	switch src.Kind {
+	case "Application":
+		return "remove the CronJob from the repository or chart of " + src.String() + " and sync; deleting it in the cluster is undone by the next sync"
+	case "Kustomization":
+		return "remove the CronJob from the Git path reconciled by " + src.String() + "; deleting it in the cluster is undone by the next reconciliation"
+	case "HelmRelease":
+		return "remove or disable the CronJob in the chart or values of " + src.String() + "; deleting it in the cluster is undone by the next reconciliation"
+	default:
+		return "remove or disable the CronJob in the chart or values of " + src.String() + " and upgrade the release; deleting it in the cluster leaves the release out of sync"
+	}
+}
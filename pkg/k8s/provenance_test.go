@@ -0,0 +1,182 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProvenanceOf(t *testing.T) {
+	day := func(d int) *metav1.Time {
+		t := metav1.NewTime(time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC))
+		return &t
+	}
+	entry := func(manager string, d int) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, Time: day(d)}
+	}
+
+	tests := []struct {
+		name           string
+		labels         map[string]string
+		annotations    map[string]string
+		managedFields  []metav1.ManagedFieldsEntry
+		wantCreatedBy  string
+		wantModifiedBy string
+		wantModifiedAt *metav1.Time
+		wantManagedBy  string
+	}{
+		{
+			name: "Created and changed by hand",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl-edit", 5),
+				entry("kubectl-client-side-apply", 1),
+				{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Time: day(9), Subresource: "status"},
+				entry("zombie-hunter", 10),
+			},
+			wantCreatedBy:  "kubectl-client-side-apply",
+			wantModifiedBy: "kubectl-edit",
+			wantModifiedAt: day(5),
+		},
+		{
+			name:          "Helm release",
+			annotations:   map[string]string{AnnotationHelmRelease: "billing"},
+			labels:        map[string]string{LabelManagedBy: "Helm"},
+			managedFields: []metav1.ManagedFieldsEntry{entry("helm", 2)},
+			wantCreatedBy: "helm", wantModifiedBy: "helm", wantModifiedAt: day(2),
+			wantManagedBy: ManagedByHelm,
+		},
+		{
+			name:          "Flux HelmRelease also carries Helm annotations",
+			annotations:   map[string]string{AnnotationHelmRelease: "billing"},
+			labels:        map[string]string{LabelFluxHelmReleaseName: "billing"},
+			managedFields: []metav1.ManagedFieldsEntry{entry("helm-controller", 2)},
+			wantCreatedBy: "helm-controller", wantModifiedBy: "helm-controller", wantModifiedAt: day(2),
+			wantManagedBy: ManagedByFlux,
+		},
+		{
+			name:          "Argo CD tracking annotation",
+			annotations:   map[string]string{AnnotationArgoTrackingID: "billing:batch/CronJob:payments/invoices"},
+			wantManagedBy: ManagedByArgoCD,
+		},
+		{
+			name:          "Argo CD field manager only",
+			managedFields: []metav1.ManagedFieldsEntry{entry("argocd-controller", 3)},
+			wantCreatedBy: "argocd-controller", wantModifiedBy: "argocd-controller", wantModifiedAt: day(3),
+			wantManagedBy: ManagedByArgoCD,
+		},
+		{
+			name:          "Other managed-by label",
+			labels:        map[string]string{LabelManagedBy: "Terraform"},
+			wantManagedBy: "Terraform",
+		},
+		{
+			name: "Nothing known",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "payments", Name: "invoices",
+				Labels: tt.labels, Annotations: tt.annotations, ManagedFields: tt.managedFields,
+			}}
+			p := ProvenanceOf(cronJob, "zombie-hunter")
+			if p.CreatedBy != tt.wantCreatedBy || p.LastModifiedBy != tt.wantModifiedBy || p.ManagedBy != tt.wantManagedBy {
+				t.Errorf("ProvenanceOf() = %+v; want created by %q, modified by %q, managed by %q",
+					p, tt.wantCreatedBy, tt.wantModifiedBy, tt.wantManagedBy)
+			}
+			switch {
+			case tt.wantModifiedAt == nil && p.LastModifiedAt != nil:
+				t.Errorf("LastModifiedAt = %v; want nil", p.LastModifiedAt)
+			case tt.wantModifiedAt != nil && (p.LastModifiedAt == nil || !p.LastModifiedAt.Equal(tt.wantModifiedAt.Time)):
+				t.Errorf("LastModifiedAt = %v; want %v", p.LastModifiedAt, tt.wantModifiedAt.Time)
+			}
+		})
+	}
+}
+
+func TestGitOpsSourceOf(t *testing.T) {
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		annotations  map[string]string
+		managers     []string
+		want         string
+		wantInAction string
+	}{
+		{
+			name:         "Argo CD tracking annotation",
+			annotations:  map[string]string{AnnotationArgoTrackingID: "billing:batch/CronJob:payments/invoices"},
+			want:         "Argo CD Application billing",
+			wantInAction: "next sync",
+		},
+		{
+			name:        "Argo CD application in another namespace",
+			annotations: map[string]string{AnnotationArgoTrackingID: "team-a_billing:batch/CronJob:payments/invoices"},
+			want:        "Argo CD Application team-a/billing",
+		},
+		{
+			name:   "Argo CD label tracking",
+			labels: map[string]string{LabelInstance: "billing"},
+			want:   "Argo CD Application billing",
+		},
+		{
+			name: "Flux Kustomization",
+			labels: map[string]string{
+				LabelFluxKustomizationName: "apps", LabelFluxKustomizationNamespace: "flux-system",
+			},
+			want:         "Flux Kustomization flux-system/apps",
+			wantInAction: "Git path",
+		},
+		{
+			name: "Flux HelmRelease wins over Helm annotations",
+			labels: map[string]string{
+				LabelFluxHelmReleaseName: "billing", LabelFluxHelmReleaseNamespace: "flux-system", LabelInstance: "billing",
+			},
+			annotations: map[string]string{AnnotationHelmRelease: "billing"},
+			want:        "Flux HelmRelease flux-system/billing",
+		},
+		{
+			name:         "Helm release wins over its instance label",
+			labels:       map[string]string{LabelInstance: "billing", LabelManagedBy: "Helm"},
+			annotations:  map[string]string{AnnotationHelmRelease: "billing", AnnotationHelmReleaseNamespace: "payments"},
+			want:         "Helm release payments/billing",
+			wantInAction: "upgrade the release",
+		},
+		{
+			name:     "Field manager only",
+			managers: []string{"kubectl-edit", "kustomize-controller"},
+			want:     "Flux Kustomization (name unknown)",
+		},
+		{
+			name:     "Not managed",
+			labels:   map[string]string{LabelManagedBy: "Terraform"},
+			managers: []string{"kubectl-client-side-apply"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels, Annotations: tt.annotations}}
+			for _, m := range tt.managers {
+				cronJob.ManagedFields = append(cronJob.ManagedFields, metav1.ManagedFieldsEntry{Manager: m})
+			}
+			src := GitOpsSourceOf(cronJob)
+			if tt.want == "" {
+				if src != nil {
+					t.Errorf("GitOpsSourceOf() = %v; want nil", src)
+				}
+				return
+			}
+			if src == nil || src.String() != tt.want {
+				t.Fatalf("GitOpsSourceOf() = %v; want %s", src, tt.want)
+			}
+			if !strings.Contains(src.Action, tt.wantInAction) || !strings.Contains(src.Action, tt.want) {
+				t.Errorf("Action = %q; want it to mention %q and %q", src.Action, tt.want, tt.wantInAction)
+			}
+		})
+	}
+}
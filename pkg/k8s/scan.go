@@ -0,0 +1,222 @@
+package k8s
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/pager"
+)
+
+// DefaultPageSize is how many objects each List call returns by default
+const DefaultPageSize = 500
+
+// maxJobNamespaces is how many namespaces a cluster-wide scan lists Jobs in
+// one by one; beyond it a single cluster-wide Job list is cheaper
+const maxJobNamespaces = 10
+
+// Inventory is everything a scan analyzes: the selected CronJobs and the
+// Jobs they own
+type Inventory struct {
+	CronJobs []batchv1.CronJob
+	Jobs     JobIndex
+	Stats    ScanStats
+}
+
+// ScanStats counts what a scan read from the API server
+type ScanStats struct {
+	Namespaces int `json:"namespaces"`
+	CronJobs   int `json:"cronJobs"`
+	Jobs       int `json:"jobs"`     // every Job listed, owned by a selected CronJob or not
+	Requests   int `json:"requests"` // List calls, one per page
+}
+
+// Add accumulates the counts of another scan
+func (s *ScanStats) Add(other ScanStats) {
+	s.Namespaces += other.Namespaces
+	s.CronJobs += other.CronJobs
+	s.Jobs += other.Jobs
+	s.Requests += other.Requests
+}
+
+// JobIndex groups Jobs by the UID of the CronJob controlling them. Matching
+// on UID rather than name keeps Jobs of a deleted CronJob away from a new
+// CronJob that reuses its name.
+type JobIndex map[types.UID][]batchv1.Job
+
+// Add files a Job under its controlling CronJob, if it has one
+func (idx JobIndex) Add(job batchv1.Job) {
+	if owner := CronJobOwner(&job); owner != "" {
+		idx[owner] = append(idx[owner], job)
+	}
+}
+
+// For returns the Jobs controlled by a CronJob
+func (idx JobIndex) For(cronJob *batchv1.CronJob) []batchv1.Job {
+	return idx[cronJob.UID]
+}
+
+// Scan lists the CronJobs selected by the filter and then their Jobs, with
+// one paginated Job list per namespace holding a selected CronJob (or one
+// cluster-wide when they span many namespaces) instead of one per CronJob. Jobs not owned by a selected CronJob are dropped page by page
+// and, unless Options.FullJobs is set, the rest are trimmed to the fields
+// the detector reads, so memory stays bounded on clusters with many Jobs.
+func (c *Client) Scan(ctx context.Context, filter Filter) (*Inventory, error) {
+	inv := &Inventory{Jobs: JobIndex{}}
+
+	namespaces, err := c.resolveNamespaces(ctx, filter, &inv.Stats)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.CronJobs, err = c.listCronJobs(ctx, namespaces, filter, &inv.Stats)
+	if err != nil {
+		return nil, err
+	}
+	if len(inv.CronJobs) == 0 {
+		return inv, nil
+	}
+
+	wanted := make(map[types.UID]bool, len(inv.CronJobs))
+	present := map[string]bool{}
+	for _, cj := range inv.CronJobs {
+		wanted[cj.UID] = true
+		present[cj.Namespace] = true
+	}
+	inv.Stats.Namespaces = len(present)
+
+	keep := func(obj runtime.Object) error {
+		job := obj.(*batchv1.Job)
+		inv.Stats.Jobs++
+		if wanted[CronJobOwner(job)] {
+			inv.Jobs.Add(c.trimJob(job))
+		}
+		return nil
+	}
+
+	if namespaces == nil && len(present) > maxJobNamespaces {
+		opts := metav1.ListOptions{FieldSelector: excludeFieldSelector(filter.ExcludeNamespaces)}
+		if err := c.each(ctx, c.jobPages(metav1.NamespaceAll), opts, &inv.Stats, keep); err != nil {
+			return nil, err
+		}
+		return inv, nil
+	}
+
+	// Only the namespaces holding a selected CronJob, so a selector that
+	// narrows the CronJobs also narrows the Jobs listed
+	for _, ns := range slices.Sorted(maps.Keys(present)) {
+		if err := c.each(ctx, c.jobPages(ns), metav1.ListOptions{}, &inv.Stats, keep); err != nil {
+			return nil, err
+		}
+	}
+	return inv, nil
+}
+
+// NamespaceLabels returns the labels of every namespace, for resolving
+// owners from namespace labels. The List calls are counted in stats.
+func (c *Client) NamespaceLabels(ctx context.Context, stats *ScanStats) (map[string]map[string]string, error) {
+	labels := map[string]map[string]string{}
+	err := c.each(ctx, c.namespacePages(), metav1.ListOptions{}, stats, func(obj runtime.Object) error {
+		ns := obj.(*corev1.Namespace)
+		labels[ns.Name] = ns.Labels
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// JobsForCronJob returns the Jobs a single CronJob controls
+func (c *Client) JobsForCronJob(ctx context.Context, cronJob *batchv1.CronJob) ([]batchv1.Job, error) {
+	idx := JobIndex{}
+	err := c.each(ctx, c.jobPages(cronJob.Namespace), metav1.ListOptions{}, &ScanStats{}, func(obj runtime.Object) error {
+		if job := obj.(*batchv1.Job); CronJobOwner(job) == cronJob.UID {
+			idx.Add(c.trimJob(job))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx.For(cronJob), nil
+}
+
+// each pages through a list, calling fn for every item
+func (c *Client) each(ctx context.Context, list pager.ListPageFunc, opts metav1.ListOptions, stats *ScanStats, fn func(runtime.Object) error) error {
+	p := pager.New(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		stats.Requests++
+		return list(ctx, opts)
+	})
+	p.PageSize = c.pageSize()
+	// Only hold one page at a time
+	p.PageBufferSize = 0
+	return p.EachListItem(ctx, opts, fn)
+}
+
+func (c *Client) namespacePages() pager.ListPageFunc {
+	return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.CoreV1().Namespaces().List(ctx, opts)
+	}
+}
+
+func (c *Client) cronJobPages(namespace string) pager.ListPageFunc {
+	return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.BatchV1().CronJobs(namespace).List(ctx, opts)
+	}
+}
+
+func (c *Client) jobPages(namespace string) pager.ListPageFunc {
+	return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.BatchV1().Jobs(namespace).List(ctx, opts)
+	}
+}
+
+func (c *Client) pageSize() int64 {
+	if c.opts.PageSize > 0 {
+		return c.opts.PageSize
+	}
+	return DefaultPageSize
+}
+
+// trimJob trims a Job unless the client keeps full Jobs
+func (c *Client) trimJob(job *batchv1.Job) batchv1.Job {
+	if c.opts.FullJobs {
+		return *job
+	}
+	return TrimJob(job)
+}
+
+// TrimJob copies the parts of a Job the detector reads: identity, owner
+// references, labels, annotations, timestamps and status. The pod template,
+// usually most of the object, is dropped.
+func TrimJob(job *batchv1.Job) batchv1.Job {
+	return batchv1.Job{
+		TypeMeta: job.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              job.Name,
+			Namespace:         job.Namespace,
+			UID:               job.UID,
+			ResourceVersion:   job.ResourceVersion,
+			Labels:            job.Labels,
+			Annotations:       job.Annotations,
+			OwnerReferences:   job.OwnerReferences,
+			CreationTimestamp: job.CreationTimestamp,
+		},
+		Status: job.Status,
+	}
+}
+
+// CronJobOwner returns the UID of the CronJob controlling a Job, or ""
+func CronJobOwner(job *batchv1.Job) types.UID {
+	owner := metav1.GetControllerOf(job)
+	if owner == nil || owner.Kind != "CronJob" {
+		return ""
+	}
+	return owner.UID
+}
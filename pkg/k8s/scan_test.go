@@ -0,0 +1,247 @@
+package k8s
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestScan(t *testing.T) {
+	billing := cronJob("team-a", "billing", nil)
+	billing.UID = "billing-v2"
+	report := cronJob("team-b", "report", nil)
+	report.UID = "report"
+
+	objects := []runtime.Object{
+		billing,
+		report,
+		ownedJob("team-a", "billing-1", "billing-v2", true),
+		ownedJob("team-a", "billing-2", "billing-v2", true),
+		// Left over from an earlier CronJob with the same name
+		ownedJob("team-a", "billing-old", "billing-v1", true),
+		// Owned but not controlled by the CronJob
+		ownedJob("team-b", "report-adopted", "report", false),
+		ownedJob("team-b", "report-1", "report", true),
+	}
+
+	c := &Client{clientset: fake.NewSimpleClientset(objects...)}
+
+	inv, err := c.Scan(context.Background(), Filter{})
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+
+	if len(inv.CronJobs) != 2 {
+		t.Fatalf("Scan() found %d CronJobs; want 2", len(inv.CronJobs))
+	}
+
+	tests := []struct {
+		cronJob  *batchv1.CronJob
+		expected []string
+	}{
+		{billing, []string{"billing-1", "billing-2"}},
+		{report, []string{"report-1"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, job := range inv.Jobs.For(tt.cronJob) {
+			got = append(got, job.Name)
+		}
+		if len(got) != len(tt.expected) {
+			t.Errorf("Jobs for %s = %v; want %v", tt.cronJob.Name, got, tt.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("Jobs for %s = %v; want %v", tt.cronJob.Name, got, tt.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestScanListsJobsOnce(t *testing.T) {
+	var objects []runtime.Object
+	for _, name := range []string{"a", "b", "c", "d"} {
+		cj := cronJob("default", name, nil)
+		cj.UID = types.UID(name)
+		objects = append(objects, cj, ownedJob("default", name+"-1", types.UID(name), true))
+	}
+
+	clientset := fake.NewSimpleClientset(objects...)
+	c := &Client{clientset: clientset}
+
+	if _, err := c.Scan(context.Background(), Filter{}); err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+
+	jobLists := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "jobs" {
+			jobLists++
+		}
+	}
+	if jobLists != 1 {
+		t.Errorf("Scan() listed Jobs %d times for 4 CronJobs; want 1", jobLists)
+	}
+}
+
+func TestScanListsJobsInSelectedNamespaces(t *testing.T) {
+	selected := cronJob("team-a", "billing", map[string]string{"tier": "batch"})
+	selected.UID = "billing"
+	objects := []runtime.Object{selected, ownedJob("team-a", "billing-1", "billing", true)}
+	for i := range maxJobNamespaces + 1 {
+		ns := "other-" + strconv.Itoa(i)
+		cj := cronJob(ns, "report", nil)
+		cj.UID = types.UID(ns)
+		objects = append(objects, cj, ownedJob(ns, "report-1", cj.UID, true))
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{name: "Selector narrows to one namespace", filter: Filter{LabelSelector: "tier=batch"}, expected: []string{"team-a"}},
+		{name: "Many namespaces", filter: Filter{}, expected: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(objects...)
+			c := &Client{clientset: clientset}
+
+			if _, err := c.Scan(context.Background(), tt.filter); err != nil {
+				t.Fatalf("Scan() failed: %v", err)
+			}
+
+			var got []string
+			for _, action := range clientset.Actions() {
+				if action.GetVerb() == "list" && action.GetResource().Resource == "jobs" {
+					got = append(got, action.GetNamespace())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Scan() listed Jobs in %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJobsForCronJob(t *testing.T) {
+	cj := cronJob("default", "billing", nil)
+	cj.UID = "billing-v2"
+
+	c := &Client{clientset: fake.NewSimpleClientset(
+		cj,
+		ownedJob("default", "billing-old", "billing-v1", true),
+		ownedJob("default", "billing-1", "billing-v2", true),
+	)}
+
+	jobs, err := c.JobsForCronJob(context.Background(), cj)
+	if err != nil {
+		t.Fatalf("JobsForCronJob() failed: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "billing-1" {
+		t.Errorf("JobsForCronJob() = %v; want [billing-1]", jobs)
+	}
+}
+
+func TestNamespaceLabels(t *testing.T) {
+	c := &Client{clientset: fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)}
+
+	var stats ScanStats
+	labels, err := c.NamespaceLabels(context.Background(), &stats)
+	if err != nil {
+		t.Fatalf("NamespaceLabels() failed: %v", err)
+	}
+	if len(labels) != 2 || labels["payments"]["team"] != "payments" {
+		t.Errorf("NamespaceLabels() = %v", labels)
+	}
+	if stats.Requests != 1 {
+		t.Errorf("Requests = %d; want 1", stats.Requests)
+	}
+}
+
+func ownedJob(namespace, name string, owner types.UID, controller bool) *batchv1.Job {
+	return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "batch/v1",
+			Kind:       "CronJob",
+			Name:       string(owner),
+			UID:        owner,
+			Controller: &controller,
+		}},
+	}}
+}
+
+func TestScanPaginatesAndTrimsJobs(t *testing.T) {
+	cj := cronJob("default", "billing", nil)
+	cj.UID = "billing"
+
+	var jobs []batchv1.Job
+	for _, name := range []string{"billing-1", "billing-2", "billing-3", "other-1", "other-2"} {
+		owner := types.UID("billing")
+		if strings.HasPrefix(name, "other") {
+			owner = "other"
+		}
+		job := ownedJob("default", name, owner, true)
+		job.Spec.Template.Spec.Containers = []corev1.Container{{Name: "main", Image: "busybox"}}
+		jobs = append(jobs, *job)
+	}
+
+	clientset := fake.NewSimpleClientset(cj)
+	clientset.PrependReactor("list", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		start := 0
+		if opts.Continue != "" {
+			start, _ = strconv.Atoi(opts.Continue)
+		}
+		end := min(start+int(opts.Limit), len(jobs))
+
+		list := &batchv1.JobList{Items: jobs[start:end]}
+		if end < len(jobs) {
+			list.Continue = strconv.Itoa(end)
+		}
+		return true, list, nil
+	})
+
+	c := &Client{clientset: clientset, opts: Options{PageSize: 2}}
+	inv, err := c.Scan(context.Background(), Filter{})
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+
+	if inv.Stats.Jobs != 5 {
+		t.Errorf("Stats.Jobs = %d; want 5", inv.Stats.Jobs)
+	}
+	// One CronJob page and three Job pages
+	if inv.Stats.Requests != 4 {
+		t.Errorf("Stats.Requests = %d; want 4", inv.Stats.Requests)
+	}
+
+	owned := inv.Jobs.For(cj)
+	if len(owned) != 3 {
+		t.Fatalf("Jobs for billing = %d; want 3", len(owned))
+	}
+	if len(owned[0].Spec.Template.Spec.Containers) != 0 {
+		t.Errorf("Job pod template was kept; want it trimmed")
+	}
+	if len(owned[0].OwnerReferences) != 1 {
+		t.Errorf("Job owner references were trimmed; want them kept")
+	}
+}
@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+)
+
+// Confidence buckets of zombie_hunter_zombies_total, matching the "high
+// confidence" line of the table report
+const (
+	BucketHigh   = "high"   // 80% and up
+	BucketMedium = "medium" // 50-79%
+	BucketLow    = "low"    // below 50%
+)
+
+var (
+	confidenceDesc = prometheus.NewDesc("zombie_hunter_cronjob_confidence",
+		"Confidence (0-99) that a zombie CronJob is abandoned.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	daysSinceSuccessDesc = prometheus.NewDesc("zombie_hunter_cronjob_days_since_success",
+		"Days since a zombie CronJob last succeeded.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	infoDesc = prometheus.NewDesc("zombie_hunter_cronjob_info",
+		"Always 1; labels describe a zombie CronJob.",
+		[]string{"cluster", "namespace", "cronjob", "schedule", "owner", "evidence_source"}, nil)
+	jobsDesc = prometheus.NewDesc("zombie_hunter_cronjob_jobs",
+		"Jobs of a zombie CronJob still in the cluster.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	failedJobsDesc = prometheus.NewDesc("zombie_hunter_cronjob_failed_jobs",
+		"Failed Jobs of a zombie CronJob still in the cluster.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	activeJobsDesc = prometheus.NewDesc("zombie_hunter_cronjob_active_jobs",
+		"Running Jobs of a zombie CronJob.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	expectedRunsDesc = prometheus.NewDesc("zombie_hunter_cronjob_expected_runs",
+		"Runs the schedule of a zombie CronJob called for within the threshold.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	missedRunsDesc = prometheus.NewDesc("zombie_hunter_cronjob_missed_runs",
+		"Expected runs of a zombie CronJob with no successful Job.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	monthlyCostDesc = prometheus.NewDesc("zombie_hunter_cronjob_estimated_monthly_cost",
+		"Estimated monthly cost of a zombie CronJob's runs, in the configured currency.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	suspendedDesc = prometheus.NewDesc("zombie_hunter_cronjob_suspended",
+		"1 if a zombie CronJob is suspended.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	lastCronJobSuccessDesc = prometheus.NewDesc("zombie_hunter_cronjob_last_success_timestamp_seconds",
+		"Unix time a zombie CronJob last succeeded, if known.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	nextRunDesc = prometheus.NewDesc("zombie_hunter_cronjob_next_run_timestamp_seconds",
+		"Unix time a zombie CronJob is next scheduled, if it isn't suspended.",
+		[]string{"cluster", "namespace", "cronjob"}, nil)
+	zombiesDesc = prometheus.NewDesc("zombie_hunter_zombies_total",
+		"Zombie CronJobs found by the last scan.",
+		[]string{"cluster", "namespace", "confidence_bucket"}, nil)
+	acknowledgedDesc = prometheus.NewDesc("zombie_hunter_acknowledged_total",
+		"CronJobs that would be zombies but were acknowledged, found by the last scan.",
+		[]string{"cluster", "namespace"}, nil)
+	cronJobsDesc = prometheus.NewDesc("zombie_hunter_cronjobs_scanned",
+		"CronJobs evaluated by the last scan.",
+		[]string{"cluster"}, nil)
+	durationDesc = prometheus.NewDesc("zombie_hunter_scan_duration_seconds",
+		"How long the last scan of a cluster took.",
+		[]string{"cluster"}, nil)
+	scanFailuresDesc = prometheus.NewDesc("zombie_hunter_scan_failures_total",
+		"Scans of a cluster that failed.",
+		[]string{"cluster"}, nil)
+	lastSuccessDesc = prometheus.NewDesc("zombie_hunter_last_successful_scan_timestamp_seconds",
+		"Unix time the last successful scan of a cluster finished.",
+		[]string{"cluster"}, nil)
+)
+
+// Exporter is a prometheus.Collector serving the results of the latest scan.
+// Series of CronJobs that are no longer zombies disappear on the next Update.
+type Exporter struct {
+	mu          sync.Mutex
+	clusters    map[string]fleet.Cluster
+	failures    map[string]int
+	lastSuccess map[string]time.Time
+}
+
+// NewExporter creates an Exporter with no results yet
+func NewExporter() *Exporter {
+	return &Exporter{
+		clusters:    map[string]fleet.Cluster{},
+		failures:    map[string]int{},
+		lastSuccess: map[string]time.Time{},
+	}
+}
+
+// Update replaces the results with those of a scan that finished at now.
+// A failed cluster keeps its previous results, so one failed scan doesn't
+// clear its alerts.
+func (e *Exporter) Update(clusters []fleet.Cluster, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, c := range clusters {
+		if c.Status == fleet.StatusFailed {
+			e.failures[c.Name]++
+			if _, ok := e.clusters[c.Name]; !ok {
+				e.clusters[c.Name] = fleet.Cluster{Name: c.Name, Status: c.Status, Duration: c.Duration}
+			}
+			continue
+		}
+		e.clusters[c.Name] = c
+		e.lastSuccess[c.Name] = now
+	}
+}
+
+// Describe implements prometheus.Collector
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{confidenceDesc, daysSinceSuccessDesc, infoDesc, jobsDesc,
+		failedJobsDesc, activeJobsDesc, expectedRunsDesc, missedRunsDesc, monthlyCostDesc,
+		suspendedDesc, lastCronJobSuccessDesc, nextRunDesc, zombiesDesc, acknowledgedDesc,
+		cronJobsDesc, durationDesc, scanFailuresDesc, lastSuccessDesc} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for name, c := range e.clusters {
+		counts := map[[2]string]int{}
+		acknowledged := map[string]int{}
+		for _, z := range c.Zombies {
+			if z.Acknowledged {
+				acknowledged[z.Namespace]++
+			}
+			if !z.IsZombie {
+				continue
+			}
+			collectZombie(ch, name, z)
+			counts[[2]string{z.Namespace, Bucket(z.Confidence)}]++
+		}
+		for key, n := range counts {
+			ch <- prometheus.MustNewConstMetric(zombiesDesc, prometheus.GaugeValue, float64(n), name, key[0], key[1])
+		}
+		for namespace, n := range acknowledged {
+			ch <- prometheus.MustNewConstMetric(acknowledgedDesc, prometheus.GaugeValue, float64(n), name, namespace)
+		}
+
+		ch <- prometheus.MustNewConstMetric(cronJobsDesc, prometheus.GaugeValue, float64(c.Scanned.CronJobs), name)
+		ch <- prometheus.MustNewConstMetric(durationDesc, prometheus.GaugeValue, c.Duration.Seconds(), name)
+		ch <- prometheus.MustNewConstMetric(scanFailuresDesc, prometheus.CounterValue, float64(e.failures[name]), name)
+		if t, ok := e.lastSuccess[name]; ok {
+			ch <- prometheus.MustNewConstMetric(lastSuccessDesc, prometheus.GaugeValue, float64(t.Unix()), name)
+		}
+	}
+}
+
+// collectZombie sends the per-CronJob series of one zombie
+func collectZombie(ch chan<- prometheus.Metric, cluster string, z detector.Zombie) {
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, cluster, z.Namespace, z.Name)
+	}
+	suspended := 0.0
+	if z.IsSuspended {
+		suspended = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1,
+		cluster, z.Namespace, z.Name, z.Schedule, z.Owner, z.EvidenceSource)
+	gauge(confidenceDesc, float64(z.Confidence))
+	gauge(daysSinceSuccessDesc, float64(z.DaysSinceSuccess))
+	gauge(jobsDesc, float64(z.TotalJobs))
+	gauge(failedJobsDesc, float64(z.FailedJobs))
+	gauge(activeJobsDesc, float64(z.ActiveJobs))
+	gauge(expectedRunsDesc, float64(z.ExpectedRuns))
+	gauge(missedRunsDesc, float64(z.MissedRuns))
+	gauge(monthlyCostDesc, z.EstimatedMonthlyCost)
+	gauge(suspendedDesc, suspended)
+	if z.LastSuccessTime != nil {
+		gauge(lastCronJobSuccessDesc, float64(z.LastSuccessTime.Unix()))
+	}
+	if z.NextScheduledRun != nil {
+		gauge(nextRunDesc, float64(z.NextScheduledRun.Unix()))
+	}
+}
+
+// Bucket returns the confidence_bucket label of a confidence
+func Bucket(confidence int) string {
+	switch {
+	case confidence >= 80:
+		return BucketHigh
+	case confidence >= 50:
+		return BucketMedium
+	default:
+		return BucketLow
+	}
+}
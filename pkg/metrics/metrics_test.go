@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+)
+
+func TestExporter(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+	e := NewExporter()
+
+	e.Update([]fleet.Cluster{{
+		Name:     "prod",
+		Status:   fleet.StatusOK,
+		Duration: 1500 * time.Millisecond,
+		Scanned:  k8s.ScanStats{CronJobs: 10},
+		Zombies: []detector.Zombie{
+			{Namespace: "billing", Name: "invoices", IsZombie: true, Confidence: 95, DaysSinceSuccess: 120},
+			{Namespace: "billing", Name: "reminders", IsZombie: true, Confidence: 60, DaysSinceSuccess: 40},
+			{Namespace: "billing", Name: "legacy", Acknowledged: true, Confidence: 90, DaysSinceSuccess: 300},
+		},
+	}}, now)
+	// A failed rescan keeps the previous results and counts the error
+	e.Update([]fleet.Cluster{{Name: "prod", Status: fleet.StatusFailed, Err: errors.New("forbidden")}}, now.Add(time.Hour))
+
+	expected := `
+# HELP zombie_hunter_scan_failures_total Scans of a cluster that failed.
+# TYPE zombie_hunter_scan_failures_total counter
+zombie_hunter_scan_failures_total{cluster="prod"} 1
+# HELP zombie_hunter_cronjob_confidence Confidence (0-99) that a zombie CronJob is abandoned.
+# TYPE zombie_hunter_cronjob_confidence gauge
+zombie_hunter_cronjob_confidence{cluster="prod",cronjob="invoices",namespace="billing"} 95
+zombie_hunter_cronjob_confidence{cluster="prod",cronjob="reminders",namespace="billing"} 60
+# HELP zombie_hunter_cronjob_days_since_success Days since a zombie CronJob last succeeded.
+# TYPE zombie_hunter_cronjob_days_since_success gauge
+zombie_hunter_cronjob_days_since_success{cluster="prod",cronjob="invoices",namespace="billing"} 120
+zombie_hunter_cronjob_days_since_success{cluster="prod",cronjob="reminders",namespace="billing"} 40
+# HELP zombie_hunter_cronjobs_scanned CronJobs evaluated by the last scan.
+# TYPE zombie_hunter_cronjobs_scanned gauge
+zombie_hunter_cronjobs_scanned{cluster="prod"} 10
+# HELP zombie_hunter_last_successful_scan_timestamp_seconds Unix time the last successful scan of a cluster finished.
+# TYPE zombie_hunter_last_successful_scan_timestamp_seconds gauge
+zombie_hunter_last_successful_scan_timestamp_seconds{cluster="prod"} 1.7672256e+09
+# HELP zombie_hunter_scan_duration_seconds How long the last scan of a cluster took.
+# TYPE zombie_hunter_scan_duration_seconds gauge
+zombie_hunter_scan_duration_seconds{cluster="prod"} 1.5
+# HELP zombie_hunter_zombies_total Zombie CronJobs found by the last scan.
+# TYPE zombie_hunter_zombies_total gauge
+zombie_hunter_zombies_total{cluster="prod",confidence_bucket="high",namespace="billing"} 1
+zombie_hunter_zombies_total{cluster="prod",confidence_bucket="medium",namespace="billing"} 1
+`
+	if err := testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"zombie_hunter_scan_failures_total", "zombie_hunter_cronjob_confidence", "zombie_hunter_cronjob_days_since_success",
+		"zombie_hunter_cronjobs_scanned", "zombie_hunter_last_successful_scan_timestamp_seconds",
+		"zombie_hunter_scan_duration_seconds", "zombie_hunter_zombies_total"); err != nil {
+		t.Error(err)
+	}
+
+	// Recovered CronJobs disappear
+	e.Update([]fleet.Cluster{{Name: "prod", Status: fleet.StatusOK}}, now.Add(2*time.Hour))
+	if n := testutil.CollectAndCount(e, "zombie_hunter_cronjob_confidence"); n != 0 {
+		t.Errorf("got %d confidence series after recovery; want 0", n)
+	}
+}
+
+func TestExporterZombieFields(t *testing.T) {
+	lastSuccess := time.Unix(1760000000, 0)
+	e := NewExporter()
+	e.Update([]fleet.Cluster{{
+		Name:   "prod",
+		Status: fleet.StatusOK,
+		Zombies: []detector.Zombie{
+			{Namespace: "billing", Name: "invoices", Schedule: "@daily", Owner: "team-billing", EvidenceSource: "jobs",
+				IsZombie: true, IsSuspended: true, Confidence: 95, TotalJobs: 3, FailedJobs: 2,
+				ExpectedRuns: 30, MissedRuns: 28, LastSuccessTime: &lastSuccess,
+				CostEstimate: detector.CostEstimate{EstimatedMonthlyCost: 12.5}},
+			{Namespace: "billing", Name: "legacy", Acknowledged: true, Confidence: 90},
+		},
+	}}, time.Now())
+
+	expected := `
+# HELP zombie_hunter_acknowledged_total CronJobs that would be zombies but were acknowledged, found by the last scan.
+# TYPE zombie_hunter_acknowledged_total gauge
+zombie_hunter_acknowledged_total{cluster="prod",namespace="billing"} 1
+# HELP zombie_hunter_cronjob_estimated_monthly_cost Estimated monthly cost of a zombie CronJob's runs, in the configured currency.
+# TYPE zombie_hunter_cronjob_estimated_monthly_cost gauge
+zombie_hunter_cronjob_estimated_monthly_cost{cluster="prod",cronjob="invoices",namespace="billing"} 12.5
+# HELP zombie_hunter_cronjob_failed_jobs Failed Jobs of a zombie CronJob still in the cluster.
+# TYPE zombie_hunter_cronjob_failed_jobs gauge
+zombie_hunter_cronjob_failed_jobs{cluster="prod",cronjob="invoices",namespace="billing"} 2
+# HELP zombie_hunter_cronjob_info Always 1; labels describe a zombie CronJob.
+# TYPE zombie_hunter_cronjob_info gauge
+zombie_hunter_cronjob_info{cluster="prod",cronjob="invoices",evidence_source="jobs",namespace="billing",owner="team-billing",schedule="@daily"} 1
+# HELP zombie_hunter_cronjob_last_success_timestamp_seconds Unix time a zombie CronJob last succeeded, if known.
+# TYPE zombie_hunter_cronjob_last_success_timestamp_seconds gauge
+zombie_hunter_cronjob_last_success_timestamp_seconds{cluster="prod",cronjob="invoices",namespace="billing"} 1.76e+09
+# HELP zombie_hunter_cronjob_missed_runs Expected runs of a zombie CronJob with no successful Job.
+# TYPE zombie_hunter_cronjob_missed_runs gauge
+zombie_hunter_cronjob_missed_runs{cluster="prod",cronjob="invoices",namespace="billing"} 28
+# HELP zombie_hunter_cronjob_suspended 1 if a zombie CronJob is suspended.
+# TYPE zombie_hunter_cronjob_suspended gauge
+zombie_hunter_cronjob_suspended{cluster="prod",cronjob="invoices",namespace="billing"} 1
+`
+	if err := testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"zombie_hunter_acknowledged_total", "zombie_hunter_cronjob_estimated_monthly_cost", "zombie_hunter_cronjob_failed_jobs", "zombie_hunter_cronjob_info",
+		"zombie_hunter_cronjob_last_success_timestamp_seconds", "zombie_hunter_cronjob_missed_runs",
+		"zombie_hunter_cronjob_suspended"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(e, "zombie_hunter_cronjob_next_run_timestamp_seconds"); n != 0 {
+		t.Errorf("got %d next run series without a next run; want 0", n)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	tests := []struct {
+		confidence int
+		want       string
+	}{
+		{99, BucketHigh},
+		{80, BucketHigh},
+		{79, BucketMedium},
+		{50, BucketMedium},
+		{49, BucketLow},
+		{0, BucketLow},
+	}
+	for _, tt := range tests {
+		if got := Bucket(tt.confidence); got != tt.want {
+			t.Errorf("Bucket(%d) = %q; want %q", tt.confidence, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+)
+
+// Email sends an HTML report over SMTP
+type Email struct {
+	addr  string
+	host  string
+	auth  smtp.Auth
+	from  string
+	to    []string
+	retry Retry
+}
+
+// NewEmail creates a notifier for an SMTP server given as
+// smtp://[user:password@]host[:port]. STARTTLS is used when the server
+// offers it, and credentials are only sent over TLS or to localhost.
+func NewEmail(server, from string, to []string) (*Email, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP URL: %w", err)
+	}
+	if u.Scheme != "smtp" || u.Hostname() == "" {
+		return nil, fmt.Errorf("SMTP URL must look like smtp://[user:password@]host[:port], got %q", u.Redacted())
+	}
+	if from == "" || len(to) == 0 {
+		return nil, fmt.Errorf("email needs from and to addresses")
+	}
+
+	e := &Email{host: u.Hostname(), from: from, to: to, retry: DefaultRetry}
+	port := u.Port()
+	if port == "" {
+		port = "587"
+	}
+	e.addr = net.JoinHostPort(e.host, port)
+	if u.User != nil {
+		password, _ := u.User.Password()
+		e.auth = smtp.PlainAuth("", u.User.Username(), password, e.host)
+	}
+	return e, nil
+}
+
+// Notify sends one email listing the top zombies
+func (e *Email) Notify(ctx context.Context, m Message) error {
+	msg, err := e.message(m)
+	if err != nil {
+		return err
+	}
+	return e.retry.do(ctx, func() error { return e.send(ctx, msg) })
+}
+
+func (e *Email) send(ctx context.Context, msg []byte) error {
+	conn, err := (&net.Dialer{Timeout: 10 * time.Second}).DialContext(ctx, "tcp", e.addr)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	c, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		conn.Close()
+		return smtpError(err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: e.host}); err != nil {
+			return smtpError(err)
+		}
+	}
+	if e.auth != nil {
+		if err := c.Auth(e.auth); err != nil {
+			return authError(err)
+		}
+	}
+	if err := c.Mail(e.from); err != nil {
+		return smtpError(err)
+	}
+	for _, to := range e.to {
+		if err := c.Rcpt(to); err != nil {
+			return smtpError(err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return smtpError(err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return smtpError(err)
+	}
+	if err := w.Close(); err != nil {
+		return smtpError(err)
+	}
+	return c.Quit()
+}
+
+// smtpError marks 5xx replies as permanent; 4xx replies and network errors
+// are worth retrying
+func smtpError(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return permanentError{err}
+	}
+	return err
+}
+
+// authError is smtpError for authentication, where errors that are neither
+// server replies nor connection failures come from the auth mechanism
+// itself, such as PLAIN refusing an unencrypted connection, and so are
+// permanent
+func authError(err error) error {
+	var reply *textproto.Error
+	var netErr net.Error
+	switch {
+	case errors.As(err, &reply):
+		return smtpError(err)
+	case errors.As(err, &netErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return err
+	default:
+		return permanentError{err}
+	}
+}
+
+var emailTemplate = template.Must(template.New("email").Funcs(template.FuncMap{"daysInactive": daysInactive}).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif">
+<h2>🧟 Zombie Hunter Report{{with .Owner}}: {{.}}{{end}}</h2>
+<p>Found {{len .Zombies}} zombie CronJobs{{with .Owner}} owned by {{.}}{{end}} with no successful run in over {{.ThresholdDays}} days.</p>
+<table cellpadding="6" style="border-collapse: collapse">
+<tr style="text-align: left; border-bottom: 1px solid #ccc">
+<th>Cluster</th><th>Namespace</th><th>CronJob</th><th>Confidence</th><th>Days inactive</th><th>Owner</th>
+</tr>
+{{- range .Top}}
+<tr>
+<td>{{.Cluster}}</td><td>{{.Namespace}}</td><td>{{.Name}}</td><td>{{.Confidence}}%</td><td>{{daysInactive .}}</td><td>{{.Owner}}</td>
+</tr>
+{{- end}}
+</table>
+{{- if .More}}
+<p>…and {{.More}} more.</p>
+{{- end}}
+<p style="color: #888">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</p>
+</body>
+</html>
+`))
+
+// message renders the headers and HTML body of an email
+func (e *Email) message(m Message) ([]byte, error) {
+	top := m.Zombies
+	if len(top) > TopZombies {
+		top = top[:TopZombies]
+	}
+
+	var body bytes.Buffer
+	err := emailTemplate.Execute(&body, struct {
+		Message
+		Top  []detector.Zombie
+		More int
+	}{m, top, len(m.Zombies) - len(top)})
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	subject := fmt.Sprintf("🧟 %d zombie CronJobs found", len(m.Zombies))
+	if m.Owner != "" {
+		subject = fmt.Sprintf("🧟 %d zombie CronJobs found for %s", len(m.Zombies), m.Owner)
+	}
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", m.GeneratedAt.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
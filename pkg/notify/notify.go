@@ -0,0 +1,196 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/config"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+)
+
+// TopZombies is how many zombies Slack and email messages list; the
+// webhook payload always carries all of them
+const TopZombies = 10
+
+// Message is what a scan sends to a notification target
+type Message struct {
+	// Zombies are the zombies routed to the target, most confident first.
+	// Acknowledged results are never sent.
+	Zombies []detector.Zombie
+	// Owner is set when the target splits its messages by owner
+	Owner         string
+	ThresholdDays int
+	GeneratedAt   time.Time
+}
+
+// daysInactive renders a zombie's days since its last success for people;
+// CronJobs that never succeeded carry a 999 sentinel
+func daysInactive(z detector.Zombie) string {
+	if z.DaysSinceSuccess >= 999 {
+		return "never"
+	}
+	return fmt.Sprintf("%d", z.DaysSinceSuccess)
+}
+
+// Notifier delivers a message to one target
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+// Route selects the zombies a target is told about
+type Route struct {
+	MinConfidence int
+	// Namespaces are names or globs such as "team-*"; empty means all
+	Namespaces []string
+	// Owners are owner names, or owner.Unowned; empty means all
+	Owners []string
+}
+
+// Select returns the zombies that pass the route, most confident first
+func (r Route) Select(zombies []detector.Zombie) []detector.Zombie {
+	filter := k8s.Filter{IncludeNamespaces: r.Namespaces}
+	var selected []detector.Zombie
+	for _, z := range zombies {
+		if z.IsZombie && !z.Acknowledged && z.Confidence >= r.MinConfidence && filter.MatchesNamespace(z.Namespace) &&
+			(len(r.Owners) == 0 || slices.Contains(r.Owners, owner.Of(z))) {
+			selected = append(selected, z)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].Confidence > selected[j].Confidence })
+	return selected
+}
+
+// Target is a configured notifier with its route
+type Target struct {
+	Name     string
+	Route    Route
+	Notifier Notifier
+	// SplitByOwner sends one message per owner instead of one in total
+	SplitByOwner bool
+}
+
+// NewTargets creates the targets of the notifications config section
+func NewTargets(notifications []config.Notification) ([]Target, error) {
+	var targets []Target
+	for i, n := range notifications {
+		var notifier Notifier
+		var err error
+		switch n.Type {
+		case "slack":
+			notifier = NewSlack(n.URL)
+		case "webhook":
+			notifier = NewWebhook(n.URL, n.Secret)
+		case "email":
+			notifier, err = NewEmail(n.URL, n.From, n.To)
+		default:
+			err = fmt.Errorf("unknown type %q", n.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("notifications[%d]: %w", i, err)
+		}
+		targets = append(targets, Target{
+			Name:         fmt.Sprintf("%s #%d", n.Type, i+1),
+			Route:        Route{MinConfidence: n.MinConfidence, Namespaces: n.Namespaces, Owners: n.Owners},
+			Notifier:     notifier,
+			SplitByOwner: n.SplitByOwner,
+		})
+	}
+	return targets, nil
+}
+
+// Send notifies every target about the zombies routed to it. Targets with
+// no zombies are skipped; one failing target doesn't stop the others.
+func Send(ctx context.Context, targets []Target, zombies []detector.Zombie, thresholdDays int, now time.Time) error {
+	var errs []error
+	for _, t := range targets {
+		selected := t.Route.Select(zombies)
+		if len(selected) == 0 {
+			continue
+		}
+		messages := []Message{{Zombies: selected}}
+		if t.SplitByOwner {
+			messages = byOwner(selected)
+		}
+		for _, m := range messages {
+			m.ThresholdDays, m.GeneratedAt = thresholdDays, now
+			if err := t.Notifier.Notify(ctx, m); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// byOwner groups zombies into one message per owner, the unowned bucket
+// last
+func byOwner(zombies []detector.Zombie) []Message {
+	names, groups := owner.Group(zombies)
+	messages := make([]Message, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, Message{Zombies: groups[name], Owner: name})
+	}
+	return messages
+}
+
+// Retry is how often and how patiently a send is retried
+type Retry struct {
+	Attempts int
+	// Backoff is the wait before the second attempt; it doubles after that
+	Backoff time.Duration
+}
+
+// DefaultRetry tries three times over about three seconds
+var DefaultRetry = Retry{Attempts: 3, Backoff: time.Second}
+
+// permanentError is a failure that retrying won't fix, such as a rejected
+// request
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// do calls send until it succeeds, fails permanently, runs out of attempts
+// or ctx is done
+func (r Retry) do(ctx context.Context, send func() error) error {
+	backoff := r.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = send(); err == nil {
+			return nil
+		}
+		var permanent permanentError
+		if errors.As(err, &permanent) || attempt >= r.Attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// httpClient is shared by the HTTP notifiers
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// checkResponse turns an HTTP error status into an error; client errors
+// other than 429 are permanent
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err := fmt.Errorf("server returned %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+	return err
+}
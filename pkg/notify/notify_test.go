@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/config"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
+)
+
+func testZombies() []detector.Zombie {
+	return []detector.Zombie{
+		{Namespace: "team-a", Name: "low", IsZombie: true, Confidence: 55, DaysSinceSuccess: 999},
+		{Namespace: "team-a", Name: "high", IsZombie: true, Confidence: 95, DaysSinceSuccess: 200, Owner: "payments"},
+		{Namespace: "team-b", Name: "other", IsZombie: true, Confidence: 85, DaysSinceSuccess: 90},
+		{Namespace: "team-a", Name: "excused", Acknowledged: true, Confidence: 99},
+	}
+}
+
+func TestRouteSelect(t *testing.T) {
+	tests := []struct {
+		name  string
+		route Route
+		want  []string
+	}{
+		{name: "Everything, most confident first", route: Route{}, want: []string{"high", "other", "low"}},
+		{name: "Min confidence", route: Route{MinConfidence: 80}, want: []string{"high", "other"}},
+		{name: "Namespace glob", route: Route{Namespaces: []string{"team-a*"}}, want: []string{"high", "low"}},
+		{name: "No match", route: Route{Namespaces: []string{"team-c"}}, want: nil},
+		{name: "Owner", route: Route{Owners: []string{"payments"}}, want: []string{"high"}},
+		{name: "Unowned", route: Route{Owners: []string{owner.Unowned}}, want: []string{"other", "low"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, z := range tt.route.Select(testZombies()) {
+				got = append(got, z.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Select() = %v; want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Select() = %v; want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	errTransient := errors.New("connection reset")
+	retry := Retry{Attempts: 3, Backoff: time.Millisecond}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "Succeeds first time", errs: []error{nil}, wantCalls: 1},
+		{name: "Succeeds after transient errors", errs: []error{errTransient, errTransient, nil}, wantCalls: 3},
+		{name: "Gives up after the last attempt", errs: []error{errTransient, errTransient, errTransient}, wantCalls: 3, wantErr: true},
+		{name: "Permanent errors aren't retried", errs: []error{permanentError{errTransient}}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retry.do(context.Background(), func() error {
+				calls++
+				return tt.errs[calls-1]
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d; want %d", calls, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("do() error = %v; wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// recorder is a Notifier remembering what it was sent
+type recorder struct{ messages []Message }
+
+func (r *recorder) Notify(ctx context.Context, m Message) error {
+	r.messages = append(r.messages, m)
+	return nil
+}
+
+func TestSend(t *testing.T) {
+	teamA, teamC := &recorder{}, &recorder{}
+	targets := []Target{
+		{Name: "team-a", Route: Route{Namespaces: []string{"team-a"}}, Notifier: teamA},
+		{Name: "team-c", Route: Route{Namespaces: []string{"team-c"}}, Notifier: teamC},
+	}
+
+	if err := Send(context.Background(), targets, testZombies(), 30, time.Now()); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+	if len(teamA.messages) != 1 || len(teamA.messages[0].Zombies) != 2 || teamA.messages[0].ThresholdDays != 30 {
+		t.Errorf("team-a got %+v; want one message with 2 zombies", teamA.messages)
+	}
+	if len(teamC.messages) != 0 {
+		t.Errorf("team-c got %d messages; want none when nothing routes to it", len(teamC.messages))
+	}
+}
+
+func TestSendSplitByOwner(t *testing.T) {
+	r := &recorder{}
+	targets := []Target{{Name: "all", Notifier: r, SplitByOwner: true}}
+
+	zombies := append(testZombies(), detector.Zombie{Namespace: "team-c", Name: "batch", IsZombie: true, Confidence: 60, Owner: "data"})
+	if err := Send(context.Background(), targets, zombies, 30, time.Now()); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+
+	var got []string
+	for _, m := range r.messages {
+		got = append(got, fmt.Sprintf("%s:%d", m.Owner, len(m.Zombies)))
+	}
+	if want := []string{"data:1", "payments:1", "unowned:2"}; !slices.Equal(got, want) {
+		t.Errorf("messages = %v; want %v", got, want)
+	}
+}
+
+func TestNewTargets(t *testing.T) {
+	targets, err := NewTargets([]config.Notification{
+		{Type: "slack", URL: "https://hooks.example.com/x", MinConfidence: 80},
+		{Type: "webhook", URL: "https://example.com/hook", Secret: "s3cret", Owners: []string{"payments"}, SplitByOwner: true},
+		{Type: "email", URL: "smtp://mail.example.com:25", From: "zh@example.com", To: []string{"ops@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("NewTargets() failed: %v", err)
+	}
+	if len(targets) != 3 || targets[0].Route.MinConfidence != 80 || targets[2].Name != "email #3" ||
+		!targets[1].SplitByOwner || targets[1].Route.Owners[0] != "payments" {
+		t.Errorf("NewTargets() = %+v", targets)
+	}
+
+	if _, err := NewTargets([]config.Notification{{Type: "email", URL: "http://mail.example.com", From: "a@b", To: []string{"c@d"}}}); err == nil {
+		t.Errorf("NewTargets() accepted a non-SMTP email URL")
+	}
+}
@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Slack posts to a Slack incoming webhook, or anything accepting the same
+// Block Kit payload
+type Slack struct {
+	url   string
+	retry Retry
+}
+
+// NewSlack creates a notifier for an incoming webhook URL
+func NewSlack(url string) *Slack {
+	return &Slack{url: url, retry: DefaultRetry}
+}
+
+// slackMessage is an incoming webhook payload. Text is the fallback shown
+// in notifications.
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Notify posts a summary and the top zombies
+func (s *Slack) Notify(ctx context.Context, m Message) error {
+	body, err := json.Marshal(slackPayload(m))
+	if err != nil {
+		return err
+	}
+
+	return s.retry.do(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+		if err != nil {
+			return permanentError{err}
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return checkResponse(resp)
+	})
+}
+
+func slackPayload(m Message) slackMessage {
+	summary := fmt.Sprintf("Found %d zombie CronJobs with no successful run in over %d days", len(m.Zombies), m.ThresholdDays)
+	header := "🧟 Zombie Hunter Report"
+	if m.Owner != "" {
+		summary = fmt.Sprintf("Found %d zombie CronJobs owned by %s with no successful run in over %d days", len(m.Zombies), m.Owner, m.ThresholdDays)
+		header += ": " + m.Owner
+	}
+	msg := slackMessage{
+		Text: "🧟 " + summary,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: header}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: summary + "."}},
+			{Type: "divider"},
+		},
+	}
+
+	top := m.Zombies
+	if len(top) > TopZombies {
+		top = top[:TopZombies]
+	}
+	for _, z := range top {
+		name := z.Namespace + "/" + z.Name
+		if z.Cluster != "" {
+			name = z.Cluster + ": " + name
+		}
+		inactive := "never succeeded"
+		if days := daysInactive(z); days != "never" {
+			inactive = days + " days inactive"
+		}
+		details := []string{fmt.Sprintf("%d%% confidence", z.Confidence), inactive}
+		if z.Owner != "" {
+			details = append(details, "owner "+z.Owner)
+		}
+		msg.Blocks = append(msg.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", name, strings.Join(details, " · "))},
+		})
+	}
+
+	footer := fmt.Sprintf("Generated %s", m.GeneratedAt.Format("2006-01-02 15:04 MST"))
+	if more := len(m.Zombies) - len(top); more > 0 {
+		footer = fmt.Sprintf("…and %d more. %s", more, footer)
+	}
+	msg.Blocks = append(msg.Blocks, slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: footer}}})
+	return msg
+}
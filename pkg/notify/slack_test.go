@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+)
+
+func TestSlackNotify(t *testing.T) {
+	var attempts int
+	var got slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var zombies []detector.Zombie
+	for i := 0; i < TopZombies+2; i++ {
+		zombies = append(zombies, detector.Zombie{Namespace: "team-a", Name: fmt.Sprintf("job-%d", i), IsZombie: true, Confidence: 90})
+	}
+
+	s := NewSlack(server.URL)
+	s.retry.Backoff = time.Millisecond
+	if err := s.Notify(context.Background(), Message{Zombies: zombies, ThresholdDays: 30, GeneratedAt: time.Now()}); err != nil {
+		t.Fatalf("Notify() failed: %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("attempts = %d; want a retry after 503", attempts)
+	}
+	if !strings.Contains(got.Text, "12 zombie CronJobs") {
+		t.Errorf("text = %q", got.Text)
+	}
+	// header, summary, divider, the top zombies and the footer
+	if want := 3 + TopZombies + 1; len(got.Blocks) != want {
+		t.Errorf("got %d blocks; want %d", len(got.Blocks), want)
+	}
+	if footer := got.Blocks[len(got.Blocks)-1]; footer.Type != "context" || !strings.Contains(footer.Elements[0].Text, "and 2 more") {
+		t.Errorf("footer = %+v", footer)
+	}
+}
+
+func TestSlackPayloadOwner(t *testing.T) {
+	msg := slackPayload(Message{Zombies: testZombies()[:1], Owner: "payments", ThresholdDays: 30})
+	if header := msg.Blocks[0].Text.Text; header != "🧟 Zombie Hunter Report: payments" {
+		t.Errorf("header = %q", header)
+	}
+	if !strings.Contains(msg.Text, "owned by payments") {
+		t.Errorf("text = %q", msg.Text)
+	}
+
+	msg = slackPayload(Message{Zombies: testZombies()[:1], ThresholdDays: 30})
+	if details := msg.Blocks[3].Text.Text; !strings.Contains(details, "never succeeded") || strings.Contains(details, "999") {
+		t.Errorf("details of a CronJob that never succeeded = %q", details)
+	}
+}
+
+func TestSlackNotifyRejected(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	s := NewSlack(server.URL)
+	s.retry.Backoff = time.Millisecond
+	if err := s.Notify(context.Background(), Message{Zombies: testZombies()[:1]}); err == nil {
+		t.Fatalf("Notify() succeeded on 403")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d; a 403 shouldn't be retried", attempts)
+	}
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+)
+
+// Headers sent by the webhook notifier
+const (
+	HeaderTimestamp = "X-Zombie-Hunter-Timestamp"
+	HeaderSignature = "X-Zombie-Hunter-Signature"
+)
+
+// Webhook posts the zombies as JSON to any URL. With a secret, requests
+// are signed so the receiver can check they came from zombie-hunter.
+type Webhook struct {
+	url    string
+	secret string
+	retry  Retry
+}
+
+// NewWebhook creates a notifier for url; an empty secret disables signing
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{url: url, secret: secret, retry: DefaultRetry}
+}
+
+// webhookPayload is the body of a webhook request
+type webhookPayload struct {
+	GeneratedAt   time.Time         `json:"generated_at"`
+	ThresholdDays int               `json:"threshold_days"`
+	Owner         string            `json:"owner,omitempty"`
+	TotalZombies  int               `json:"total_zombies"`
+	Zombies       []detector.Zombie `json:"zombies"`
+}
+
+// Notify posts every routed zombie. Each attempt is signed afresh, so a
+// retried request carries a current timestamp.
+func (w *Webhook) Notify(ctx context.Context, m Message) error {
+	body, err := json.Marshal(webhookPayload{
+		GeneratedAt:   m.GeneratedAt,
+		ThresholdDays: m.ThresholdDays,
+		Owner:         m.Owner,
+		TotalZombies:  len(m.Zombies),
+		Zombies:       m.Zombies,
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.retry.do(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+		if err != nil {
+			return permanentError{err}
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if w.secret != "" {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set(HeaderTimestamp, timestamp)
+			req.Header.Set(HeaderSignature, Sign(w.secret, timestamp, body))
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return checkResponse(resp)
+	})
+}
+
+// Sign returns the signature of a webhook request: "sha256=" and the hex
+// HMAC-SHA256 of the timestamp header, a dot and the body. Receivers should
+// compute it themselves, compare with hmac.Equal and reject old timestamps.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	const secret = "s3cret"
+	var payload webhookPayload
+	var signatureOK bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := Sign(secret, r.Header.Get(HeaderTimestamp), body)
+		signatureOK = hmac.Equal([]byte(r.Header.Get(HeaderSignature)), []byte(want))
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	zombies := Route{}.Select(testZombies())
+	if err := NewWebhook(server.URL, secret).Notify(context.Background(), Message{Zombies: zombies, ThresholdDays: 30, GeneratedAt: time.Now()}); err != nil {
+		t.Fatalf("Notify() failed: %v", err)
+	}
+
+	if !signatureOK {
+		t.Errorf("signature doesn't verify")
+	}
+	if payload.TotalZombies != 3 || len(payload.Zombies) != 3 || payload.Zombies[0].Name != "high" || payload.ThresholdDays != 30 {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestSign(t *testing.T) {
+	a := Sign("secret", "1700000000", []byte(`{}`))
+	if a != Sign("secret", "1700000000", []byte(`{}`)) {
+		t.Errorf("Sign() isn't deterministic")
+	}
+	for _, other := range []string{
+		Sign("other", "1700000000", []byte(`{}`)),
+		Sign("secret", "1700000001", []byte(`{}`)),
+		Sign("secret", "1700000000", []byte(`{"x":1}`)),
+	} {
+		if other == a {
+			t.Errorf("Sign() ignores part of its input")
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package owner
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Unowned is the owner bucket of CronJobs no rule resolved an owner for
+const Unowned = "unowned"
+
+// DefaultKeys are the labels and annotations, on the CronJob and then on its
+// namespace, that name an owner
+var DefaultKeys = []string{"team", "owner", "app.kubernetes.io/part-of"}
+
+// Mapping assigns the CronJobs of matching namespaces to an owner
+type Mapping struct {
+	// Namespace is a name or glob such as "payments-*"
+	Namespace string `json:"namespace"`
+	Owner     string `json:"owner"`
+}
+
+// Resolver finds who is responsible for a CronJob
+type Resolver struct {
+	keys     []string
+	mappings []Mapping
+}
+
+// NewResolver creates a resolver trying keys in order, then mappings in
+// order
+func NewResolver(keys []string, mappings []Mapping) *Resolver {
+	return &Resolver{keys: keys, mappings: mappings}
+}
+
+// LoadMappings reads a YAML list of namespace-to-owner mappings
+func LoadMappings(file string) ([]Mapping, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var mappings []Mapping
+	if err := yaml.UnmarshalStrict(data, &mappings); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for i, m := range mappings {
+		if m.Namespace == "" || m.Owner == "" {
+			return nil, fmt.Errorf("%s: mapping %d needs a namespace and an owner", file, i)
+		}
+		if _, err := path.Match(m.Namespace, ""); err != nil {
+			return nil, fmt.Errorf("%s: mapping %d: invalid namespace pattern %q", file, i, m.Namespace)
+		}
+	}
+	return mappings, nil
+}
+
+// Resolve returns a CronJob's owner and the rule that resolved it, such as
+// "label team" or "mapping payments-*". The first match wins:
+//
+//  1. the zombie-hunter.io/owner annotation
+//  2. each key as a label, then as an annotation, of the CronJob
+//  3. each key as a label of the namespace
+//  4. the first mapping matching the namespace
+//
+// An unresolved CronJob has an empty owner and source.
+func (r *Resolver) Resolve(cronJob *batchv1.CronJob, namespaceLabels map[string]string) (owner, source string) {
+	if v := strings.TrimSpace(cronJob.Annotations[detector.AnnotationOwner]); v != "" {
+		return v, "annotation " + detector.AnnotationOwner
+	}
+	for _, key := range r.keys {
+		if v := strings.TrimSpace(cronJob.Labels[key]); v != "" {
+			return v, "label " + key
+		}
+		if v := strings.TrimSpace(cronJob.Annotations[key]); v != "" {
+			return v, "annotation " + key
+		}
+	}
+	for _, key := range r.keys {
+		if v := strings.TrimSpace(namespaceLabels[key]); v != "" {
+			return v, "namespace label " + key
+		}
+	}
+	for _, m := range r.mappings {
+		if ok, _ := path.Match(m.Namespace, cronJob.Namespace); ok {
+			return m.Owner, "mapping " + m.Namespace
+		}
+	}
+	return "", ""
+}
+
+// NeedsNamespaceLabels reports whether Resolve reads namespace labels, so
+// callers can skip listing namespaces when it doesn't
+func (r *Resolver) NeedsNamespaceLabels() bool {
+	return len(r.keys) > 0
+}
+
+// Of returns the owner bucket of a result: its owner, or Unowned
+func Of(z detector.Zombie) string {
+	if z.Owner == "" {
+		return Unowned
+	}
+	return z.Owner
+}
+
+// Group buckets results by owner. Owners are returned in name order with
+// Unowned last.
+func Group(zombies []detector.Zombie) (owners []string, groups map[string][]detector.Zombie) {
+	groups = map[string][]detector.Zombie{}
+	for _, z := range zombies {
+		groups[Of(z)] = append(groups[Of(z)], z)
+	}
+	owners = slices.Sorted(maps.Keys(groups))
+	if i := slices.Index(owners, Unowned); i >= 0 {
+		owners = append(slices.Delete(owners, i, i+1), Unowned)
+	}
+	return owners, groups
+}
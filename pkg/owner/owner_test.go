@@ -0,0 +1,144 @@
+package owner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolve(t *testing.T) {
+	r := NewResolver(DefaultKeys, []Mapping{
+		{Namespace: "payments-*", Owner: "payments"},
+		{Namespace: "*", Owner: "platform"},
+	})
+
+	tests := []struct {
+		name            string
+		namespace       string
+		labels          map[string]string
+		annotations     map[string]string
+		namespaceLabels map[string]string
+		wantOwner       string
+		wantSource      string
+	}{
+		{
+			name:        "Owner annotation wins",
+			labels:      map[string]string{"team": "label-team"},
+			annotations: map[string]string{detector.AnnotationOwner: "annotated"},
+			wantOwner:   "annotated",
+			wantSource:  "annotation zombie-hunter.io/owner",
+		},
+		{
+			name:       "CronJob label",
+			labels:     map[string]string{"app.kubernetes.io/part-of": "checkout", "team": "payments"},
+			wantOwner:  "payments",
+			wantSource: "label team",
+		},
+		{
+			name:        "CronJob annotation",
+			annotations: map[string]string{"owner": "alice"},
+			wantOwner:   "alice",
+			wantSource:  "annotation owner",
+		},
+		{
+			name:            "Namespace label",
+			namespaceLabels: map[string]string{"team": "data"},
+			wantOwner:       "data",
+			wantSource:      "namespace label team",
+		},
+		{
+			name:       "First matching mapping",
+			namespace:  "payments-eu",
+			wantOwner:  "payments",
+			wantSource: "mapping payments-*",
+		},
+		{
+			name:       "Catch-all mapping",
+			wantOwner:  "platform",
+			wantSource: "mapping *",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := tt.namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace, Name: "job", Labels: tt.labels, Annotations: tt.annotations,
+			}}
+			owner, source := r.Resolve(cronJob, tt.namespaceLabels)
+			if owner != tt.wantOwner || source != tt.wantSource {
+				t.Errorf("Resolve() = %q, %q; want %q, %q", owner, source, tt.wantOwner, tt.wantSource)
+			}
+		})
+	}
+
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job"}}
+	if owner, source := NewResolver(nil, nil).Resolve(cronJob, nil); owner != "" || source != "" {
+		t.Errorf("Resolve() without rules = %q, %q; want unresolved", owner, source)
+	}
+	if got := Of(detector.Zombie{}); got != Unowned {
+		t.Errorf("Of() = %q; want %q", got, Unowned)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	owners, groups := Group([]detector.Zombie{
+		{Name: "a", Owner: "payments"},
+		{Name: "b"},
+		{Name: "c", Owner: "data"},
+		{Name: "d", Owner: "payments"},
+	})
+	if want := []string{"data", "payments", Unowned}; !slices.Equal(owners, want) {
+		t.Errorf("Group() owners = %v; want %v", owners, want)
+	}
+	if len(groups["payments"]) != 2 || groups[Unowned][0].Name != "b" {
+		t.Errorf("Group() groups = %v", groups)
+	}
+}
+
+func TestLoadMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			content: `
+- namespace: payments-*
+  owner: payments
+- namespace: data
+  owner: data-eng
+`,
+			want: 2,
+		},
+		{name: "Missing owner", content: "- namespace: data\n", wantErr: true},
+		{name: "Bad pattern", content: "- namespace: \"[\"\n  owner: x\n", wantErr: true},
+		{name: "Unknown field", content: "- namespace: data\n  team: x\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "owners.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			mappings, err := LoadMappings(file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMappings() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if len(mappings) != tt.want {
+				t.Errorf("LoadMappings() = %v; want %d mappings", mappings, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// Policy is a set of declarative zombie rules loaded from a YAML file
+type Policy struct {
+	Rules []RuleSpec `json:"rules"`
+
+	compiled []*celRule
+}
+
+// RuleSpec is one rule in a policy file. Expression is a CEL predicate; when
+// it evaluates to true the rule emits a signal with Score and Message.
+type RuleSpec struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Score      int    `json:"score"`
+	Message    string `json:"message"`
+	Verdict    string `json:"verdict,omitempty"`
+}
+
+// Variables available to policy expressions
+var variables = []cel.EnvOption{
+	cel.Variable("cronJob", cel.DynType),
+	cel.Variable("jobs", cel.ListType(cel.DynType)),
+	cel.Variable("name", cel.StringType),
+	cel.Variable("ns", cel.StringType), // "namespace" is reserved in CEL
+	cel.Variable("labels", cel.MapType(cel.StringType, cel.StringType)),
+	cel.Variable("annotations", cel.MapType(cel.StringType, cel.StringType)),
+	cel.Variable("schedule", cel.StringType),
+	cel.Variable("thresholdDays", cel.IntType),
+	cel.Variable("daysSinceSuccess", cel.IntType),
+	cel.Variable("totalJobs", cel.IntType),
+	cel.Variable("failedJobs", cel.IntType),
+	cel.Variable("activeJobs", cel.IntType),
+	cel.Variable("suspended", cel.BoolType),
+	cel.Variable("hasRun", cel.BoolType),
+	cel.Variable("expectedRuns", cel.IntType),
+	cel.Variable("missedRuns", cel.IntType),
+	cel.Variable("evidenceSource", cel.StringType),
+}
+
+// Load reads, validates and compiles a policy file
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse validates and compiles a policy document
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	env, err := cel.NewEnv(variables...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for i, spec := range p.Rules {
+		where := fmt.Sprintf("rules[%d]", i)
+		if spec.Name != "" {
+			where = fmt.Sprintf("rule %q (rules[%d])", spec.Name, i)
+		}
+
+		if err := spec.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", where, err)
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("%s: duplicate rule name", where)
+		}
+		seen[spec.Name] = true
+
+		ast, issues := env.Compile(spec.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("%s: expression: %w", where, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("%s: expression must evaluate to bool, got %s", where, ast.OutputType())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expression: %w", where, err)
+		}
+
+		p.compiled = append(p.compiled, &celRule{spec: spec, program: program})
+	}
+
+	return &p, nil
+}
+
+func (s RuleSpec) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(s.Expression) == "" {
+		return fmt.Errorf("expression is required")
+	}
+	if s.Message == "" {
+		return fmt.Errorf("message is required")
+	}
+	if s.Score < -99 || s.Score > 99 {
+		return fmt.Errorf("score must be between -99 and 99, got %d", s.Score)
+	}
+	switch s.Verdict {
+	case "", detector.VerdictZombie, detector.VerdictIgnore:
+	default:
+		return fmt.Errorf("verdict must be %q or %q, got %q", detector.VerdictZombie, detector.VerdictIgnore, s.Verdict)
+	}
+	return nil
+}
+
+// DetectorRules returns the policy's rules for registration with a detector
+func (p *Policy) DetectorRules() []detector.Rule {
+	rules := make([]detector.Rule, 0, len(p.compiled))
+	for _, r := range p.compiled {
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// celRule adapts a compiled policy rule to detector.Rule
+type celRule struct {
+	spec    RuleSpec
+	program cel.Program
+}
+
+func (r *celRule) Name() string {
+	return r.spec.Name
+}
+
+func (r *celRule) Evaluate(in *detector.Input) []detector.Signal {
+	vars, err := activation(in)
+	if err != nil {
+		return []detector.Signal{r.errorSignal(err)}
+	}
+
+	out, _, err := r.program.Eval(vars)
+	if err != nil {
+		return []detector.Signal{r.errorSignal(err)}
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok || !matched {
+		return nil
+	}
+
+	return []detector.Signal{{
+		Name:     r.spec.Name,
+		Weight:   r.spec.Score,
+		Observed: "matched",
+		Message:  r.spec.Message,
+		Verdict:  r.spec.Verdict,
+	}}
+}
+
+// errorSignal reports a rule that failed at runtime without affecting the score
+func (r *celRule) errorSignal(err error) detector.Signal {
+	return detector.Signal{
+		Name:     r.spec.Name,
+		Observed: "error",
+		Message:  fmt.Sprintf("policy rule failed to evaluate: %v", err),
+	}
+}
+
+// activation builds the variables a policy expression is evaluated against
+func activation(in *detector.Input) (map[string]any, error) {
+	cronJob, err := runtime.DefaultUnstructuredConverter.ToUnstructured(in.CronJob)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]any, 0, len(in.Jobs))
+	for i := range in.Jobs {
+		job, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&in.Jobs[i])
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	expectedRuns, missedRuns := 0, 0
+	if in.Schedule != nil {
+		expectedRuns = in.Schedule.ExpectedRuns
+		missedRuns = in.Schedule.MissedRuns
+	}
+
+	return map[string]any{
+		"cronJob":          cronJob,
+		"jobs":             jobs,
+		"name":             in.CronJob.Name,
+		"ns":               in.CronJob.Namespace,
+		"labels":           stringMap(in.CronJob.Labels),
+		"annotations":      stringMap(in.CronJob.Annotations),
+		"schedule":         in.CronJob.Spec.Schedule,
+		"thresholdDays":    in.ThresholdDays,
+		"daysSinceSuccess": in.DaysSinceSuccess,
+		"totalJobs":        in.TotalJobs,
+		"failedJobs":       in.FailedJobs,
+		"activeJobs":       in.ActiveJobs,
+		"suspended":        in.Suspended,
+		"hasRun":           in.HasRun,
+		"expectedRuns":     expectedRuns,
+		"missedRuns":       missedRuns,
+		"evidenceSource":   in.EvidenceSource,
+	}, nil
+}
+
+func stringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,213 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{
+			name: "Valid policy",
+			policy: `
+rules:
+  - name: sandbox-expiry
+    expression: ns.startsWith("sandbox-") && daysSinceSuccess >= 7
+    score: 90
+    message: sandbox jobs expire after 7 days
+    verdict: zombie
+  - name: argocd
+    expression: '"argocd.argoproj.io/instance" in labels'
+    score: 0
+    message: managed by Argo CD
+    verdict: ignore
+`,
+		},
+		{
+			name: "Syntax error points at the rule",
+			policy: `
+rules:
+  - name: broken
+    expression: daysSinceSuccess >=
+    score: 50
+    message: broken
+`,
+			wantErr: `rule "broken" (rules[0]): expression:`,
+		},
+		{
+			name: "Undeclared variable",
+			policy: `
+rules:
+  - name: typo
+    expression: daysSinceSucess > 7
+    score: 50
+    message: typo
+`,
+			wantErr: "undeclared reference",
+		},
+		{
+			name: "Non-boolean expression",
+			policy: `
+rules:
+  - name: number
+    expression: daysSinceSuccess + 1
+    score: 50
+    message: number
+`,
+			wantErr: "must evaluate to bool",
+		},
+		{
+			name: "Missing name",
+			policy: `
+rules:
+  - expression: "true"
+    score: 50
+    message: anonymous
+`,
+			wantErr: "rules[0]: name is required",
+		},
+		{
+			name: "Duplicate name",
+			policy: `
+rules:
+  - name: twice
+    expression: "true"
+    score: 10
+    message: one
+  - name: twice
+    expression: "false"
+    score: 10
+    message: two
+`,
+			wantErr: `rule "twice" (rules[1]): duplicate rule name`,
+		},
+		{
+			name: "Unknown verdict",
+			policy: `
+rules:
+  - name: maybe
+    expression: "true"
+    score: 10
+    message: maybe
+    verdict: perhaps
+`,
+			wantErr: "verdict must be",
+		},
+		{
+			name: "Unknown field",
+			policy: `
+rules:
+  - name: extra
+    expresion: "true"
+    score: 10
+    message: extra
+`,
+			wantErr: "unknown field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.policy))
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Parse() error = %v; want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Parse() error = %v; want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyRulesInDetector(t *testing.T) {
+	p, err := Parse([]byte(`
+rules:
+  - name: sandbox-expiry
+    expression: ns.startsWith("sandbox-") && daysSinceSuccess >= 7
+    score: 90
+    message: sandbox jobs expire after 7 days
+    verdict: zombie
+  - name: dr-jobs
+    expression: cronJob.metadata.labels.exists(k, k == "disaster-recovery")
+    score: 0
+    message: disaster recovery jobs run on demand
+    verdict: ignore
+`))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	rules := detector.DefaultRegistry()
+	for _, r := range p.DetectorRules() {
+		if err := rules.Register(r); err != nil {
+			t.Fatalf("Register() failed: %v", err)
+		}
+	}
+	d := detector.NewDetector(rules, 30)
+
+	now := time.Now()
+	tests := []struct {
+		name             string
+		namespace        string
+		labels           map[string]string
+		lastSuccess      time.Time
+		expectedIsZombie bool
+	}{
+		{
+			name:             "Sandbox job quiet for 10 days",
+			namespace:        "sandbox-bob",
+			lastSuccess:      now.Add(-10 * 24 * time.Hour),
+			expectedIsZombie: true,
+		},
+		{
+			name:             "Regular job quiet for 10 days",
+			namespace:        "payments",
+			lastSuccess:      now.Add(-10 * 24 * time.Hour),
+			expectedIsZombie: false,
+		},
+		{
+			name:             "Disaster recovery job is ignored",
+			namespace:        "payments",
+			labels:           map[string]string{"disaster-recovery": "true"},
+			lastSuccess:      now.Add(-300 * 24 * time.Hour),
+			expectedIsZombie: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: tt.namespace, Labels: tt.labels},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *"},
+			}
+			jobs := []batchv1.Job{
+				{
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:               batchv1.JobComplete,
+								Status:             v1.ConditionTrue,
+								LastTransitionTime: metav1.NewTime(tt.lastSuccess),
+							},
+						},
+					},
+				},
+			}
+
+			zombie := d.Analyze(cronJob, jobs)
+			if zombie.IsZombie != tt.expectedIsZombie {
+				t.Errorf("IsZombie = %v; want %v (signals: %+v)", zombie.IsZombie, tt.expectedIsZombie, zombie.Signals)
+			}
+		})
+	}
+}
@@ -4,53 +4,119 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"math"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/prometheus/common/expfmt"
 	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+	"github.com/rrdesai64/zombie-hunter/pkg/k8s"
+	"github.com/rrdesai64/zombie-hunter/pkg/owner"
 )
 
 type Formatter struct {
 	format string
+	out    io.Writer
 }
 
 func NewFormatter(format string) *Formatter {
-	return &Formatter{format: format}
+	return &Formatter{format: format, out: os.Stdout}
 }
 
-func (f *Formatter) Output(zombies []detector.Zombie, thresholdDays int) error {
+// Result is the outcome of a scan
+type Result struct {
+	// Clusters holds every cluster scanned, in scan order, with its zombies
+	// and acknowledged results
+	Clusters      []fleet.Cluster
+	ThresholdDays int
+	// Currency is what EstimatedMonthlyCost is in, e.g. "USD"
+	Currency string
+}
+
+// Output writes a scan report. Results are grouped by cluster, and in the
+// table also by owner, and acknowledged results are listed apart from the
+// zombies so they are counted without being reported as work to do.
+func (f *Formatter) Output(r Result) error {
+	results := byCost(r.Clusters)
+	zombies, acknowledged := splitAcknowledged(results)
+
 	switch f.format {
 	case "json":
-		return f.outputJSON(zombies)
+		return f.outputJSON(r, zombies, acknowledged)
 	case "csv":
-		return f.outputCSV(zombies)
+		return f.outputCSV(results)
+	case "prometheus":
+		return f.outputPrometheus(r, expfmt.NewFormat(expfmt.TypeTextPlain))
+	case "openmetrics":
+		return f.outputPrometheus(r, expfmt.NewFormat(expfmt.TypeOpenMetrics))
 	default:
-		return f.outputTable(zombies, thresholdDays)
+		return f.outputTable(r, zombies, acknowledged)
 	}
 }
 
-func (f *Formatter) outputTable(zombies []detector.Zombie, thresholdDays int) error {
-	fmt.Printf("\n🧟 ZOMBIE HUNTER REPORT\n")
-	fmt.Printf("Generated: %s\n", time.Now().Format("2006-01-02 15:04:05"))
-	fmt.Printf("Threshold: %d days\n\n", thresholdDays)
+func (f *Formatter) outputTable(r Result, zombies, acknowledged []detector.Zombie) error {
+	multiCluster := len(r.Clusters) > 1
+	scanned := fleet.Scanned(r.Clusters)
+
+	fmt.Fprintf(f.out, "\n🧟 ZOMBIE HUNTER REPORT\n")
+	fmt.Fprintf(f.out, "Generated: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(f.out, "Threshold: %d days\n", r.ThresholdDays)
+	if multiCluster {
+		var names []string
+		for _, c := range r.Clusters {
+			names = append(names, c.Name)
+		}
+		fmt.Fprintf(f.out, "Clusters:  %s\n", strings.Join(names, ", "))
+	}
+	fmt.Fprintf(f.out, "Scanned:   %d CronJobs, %d Jobs in %d namespaces (%d API requests)\n",
+		scanned.CronJobs, scanned.Jobs, scanned.Namespaces, scanned.Requests)
+	for _, c := range fleet.Failed(r.Clusters) {
+		fmt.Fprintf(f.out, "❌ %s: scan failed, results are partial: %v\n", c.Name, c.Err)
+	}
+	fmt.Fprintf(f.out, "\n")
 
 	if len(zombies) == 0 {
-		fmt.Printf("✅ No zombies found! All CronJobs are healthy.\n\n")
+		fmt.Fprintf(f.out, "✅ No zombies found! All CronJobs are healthy.\n")
+		if len(acknowledged) > 0 {
+			fmt.Fprintf(f.out, "Acknowledged: %s\n", summarizeAcknowledged(acknowledged))
+		}
+		fmt.Fprintf(f.out, "\n")
 		return nil
 	}
 
-	fmt.Printf("%s\n", strings.Repeat("━", 80))
-	fmt.Printf("ZOMBIE CANDIDATES (%d found)\n", len(zombies))
-	fmt.Printf("%s\n\n", strings.Repeat("━", 80))
+	fmt.Fprintf(f.out, "%s\n", strings.Repeat("━", 80))
+	fmt.Fprintf(f.out, "ZOMBIE CANDIDATES (%d found)\n", len(zombies))
+	fmt.Fprintf(f.out, "%s\n\n", strings.Repeat("━", 80))
 
 	// Simple table output
-	fmt.Printf("%-4s %-30s %-15s %-15s %-12s %-20s\n",
-		"🔍", "NAME", "NAMESPACE", "DAYS INACTIVE", "CONFIDENCE", "JOBS")
-	fmt.Printf("%s\n", strings.Repeat("-", 100))
+	fmt.Fprintf(f.out, "%-4s %-30s %-15s %-15s %-13s %-12s %-14s %-12s %-20s\n",
+		"🔍", "NAME", "NAMESPACE", "DAYS INACTIVE", "MISSED RUNS", "CONFIDENCE", "COST/MONTH", "MANAGED BY", "JOBS")
+	fmt.Fprintf(f.out, "%s\n", strings.Repeat("-", 142))
+
+	// Group by owner only once some owner was resolved, a single unowned
+	// group would just repeat the table
+	zombies = byOwner(zombies)
+	grouped := slices.ContainsFunc(zombies, func(z detector.Zombie) bool { return z.Owner != "" })
+
+	highConf, gitOps := 0, 0
+	savings := 0.0
+	perCluster := map[string]int{}
+	for i, z := range zombies {
+		newCluster := i == 0 || zombies[i-1].Cluster != z.Cluster
+		if multiCluster && newCluster {
+			fmt.Fprintf(f.out, "\n☸️  %s\n", z.Cluster)
+		}
+		if grouped && (newCluster || owner.Of(zombies[i-1]) != owner.Of(z)) {
+			fmt.Fprintf(f.out, "\n👤 %s\n", owner.Of(z))
+		}
+		perCluster[z.Cluster]++
 
-	highConf := 0
-	for _, z := range zombies {
 		emoji := getEmoji(z.Confidence)
 
 		daysStr := fmt.Sprintf("%d", z.DaysSinceSuccess)
@@ -58,10 +124,18 @@ func (f *Formatter) outputTable(zombies []detector.Zombie, thresholdDays int) er
 			daysStr = "NEVER"
 		}
 
+		missedStr := "-"
+		if z.ExpectedRuns > 0 {
+			missedStr = fmt.Sprintf("%d/%d", z.MissedRuns, z.ExpectedRuns)
+		}
+
 		jobsStr := fmt.Sprintf("%d total, %d failed", z.TotalJobs, z.FailedJobs)
 		if z.IsSuspended {
 			jobsStr += " (susp.)"
 		}
+		if z.EvidenceSource == detector.EvidenceCronJobStatus {
+			jobsStr += " (status)"
+		}
 
 		// Truncate long names
 		name := z.Name
@@ -69,47 +143,97 @@ func (f *Formatter) outputTable(zombies []detector.Zombie, thresholdDays int) er
 			name = name[:25] + "..."
 		}
 
-		fmt.Printf("%-4s %-30s %-15s %-15s %-12s %-20s\n",
+		managedBy := z.ManagedBy
+		if managedBy == "" {
+			managedBy = "-"
+		}
+
+		fmt.Fprintf(f.out, "%-4s %-30s %-15s %-15s %-13s %-12s %-14s %-12s %-20s\n",
 			emoji,
 			name,
 			z.Namespace,
 			daysStr,
+			missedStr,
 			fmt.Sprintf("%d%%", z.Confidence),
+			formatCost(z.EstimatedMonthlyCost, r.Currency),
+			managedBy,
 			jobsStr,
 		)
 
+		// Show why it was flagged
+		for _, s := range z.Signals {
+			if s.Weight != 0 {
+				fmt.Fprintf(f.out, "%-4s ↳ %s\n", "", s.Message)
+			}
+		}
+		if z.Owner != "" {
+			fmt.Fprintf(f.out, "%-4s ↳ owner: %s (%s)\n", "", z.Owner, z.OwnerSource)
+		}
+		if src := z.GitOps; src != nil {
+			fmt.Fprintf(f.out, "%-4s ↳ managed by %s: %s\n", "", src, src.Action)
+			gitOps++
+		}
+
 		if z.Confidence >= 80 {
 			highConf++
 		}
+		savings += z.EstimatedMonthlyCost
 	}
 
-	fmt.Printf("\n%s\n", strings.Repeat("━", 80))
-	fmt.Printf("SUMMARY\n")
-	fmt.Printf("%s\n\n", strings.Repeat("━", 80))
+	fmt.Fprintf(f.out, "\n%s\n", strings.Repeat("━", 80))
+	fmt.Fprintf(f.out, "SUMMARY\n")
+	fmt.Fprintf(f.out, "%s\n\n", strings.Repeat("━", 80))
 
-	fmt.Printf("Total zombies found: %d\n", len(zombies))
-	fmt.Printf("High confidence (≥80%%): %d\n", highConf)
+	fmt.Fprintf(f.out, "Total zombies found: %d\n", len(zombies))
+	if multiCluster {
+		for _, c := range r.Clusters {
+			if c.Status == fleet.StatusFailed {
+				fmt.Fprintf(f.out, "  %s: scan failed\n", c.Name)
+				continue
+			}
+			fmt.Fprintf(f.out, "  %s: %d\n", c.Name, perCluster[c.Name])
+		}
+	}
+	fmt.Fprintf(f.out, "High confidence (≥80%%): %d\n", highConf)
+	fmt.Fprintf(f.out, "Potential savings: %s/month (estimated)\n", formatCost(savings, r.Currency))
+	if owners, groups := owner.Group(zombies); len(owners) > 0 {
+		fmt.Fprintf(f.out, "By owner:\n")
+		for _, o := range owners {
+			fmt.Fprintf(f.out, "  %s: %d\n", o, len(groups[o]))
+		}
+	}
+	if len(acknowledged) > 0 {
+		fmt.Fprintf(f.out, "Acknowledged: %s\n", summarizeAcknowledged(acknowledged))
+	}
 
 	if highConf > 0 {
-		fmt.Printf("\n💡 Tip: Start by reviewing high-confidence zombies\n")
+		fmt.Fprintf(f.out, "\n💡 Tip: Start by reviewing high-confidence zombies\n")
 	}
 
-	fmt.Printf("\nNext steps:\n")
-	fmt.Printf("1. Review each zombie with your team\n")
-	fmt.Printf("2. Delete safely: kubectl delete cronjob <name> -n <namespace>\n")
-	fmt.Printf("3. Try different thresholds: --days 60 or --days 90\n\n")
+	fmt.Fprintf(f.out, "\nNext steps:\n")
+	fmt.Fprintf(f.out, "1. Review each zombie with your team\n")
+	fmt.Fprintf(f.out, "2. Delete safely (backed up, undo with restore): zombie-hunter delete <namespace>/<name>\n")
+	if gitOps > 0 {
+		fmt.Fprintf(f.out, "   %d managed by Argo CD, Flux or Helm: remove them at their source instead, delete skips them\n", gitOps)
+	}
+	fmt.Fprintf(f.out, "3. Try different thresholds: --days 60 or --days 90\n\n")
 
 	return nil
 }
 
 func (f *Formatter) outputCSV(zombies []detector.Zombie) error {
-	w := csv.NewWriter(os.Stdout)
+	w := csv.NewWriter(f.out)
 	defer w.Flush()
 
-	w.Write([]string{"Name", "Namespace", "Schedule", "DaysSinceSuccess", "TotalJobs", "FailedJobs", "Confidence", "Suspended"})
+	w.Write([]string{"Cluster", "Name", "Namespace", "Schedule", "DaysSinceSuccess", "TotalJobs", "FailedJobs", "Confidence", "Suspended", "ExpectedRuns", "MissedRuns", "NextScheduledRun", "EvidenceSource", "Owner", "OwnerSource", "CreatedBy", "LastModifiedBy", "LastModifiedAt", "ManagedBy", "GitOpsSource", "SuggestedAction", "MonthlyRuns", "RunDurationSeconds", "MonthlyCPUHours", "MonthlyMemoryGBHours", "MonthlyGPUHours", "EstimatedMonthlyCost", "CostBasis", "Acknowledged", "Signals"})
 
 	for _, z := range zombies {
+		var gitOpsSource, suggestedAction string
+		if z.GitOps != nil {
+			gitOpsSource, suggestedAction = z.GitOps.String(), z.GitOps.Action
+		}
 		w.Write([]string{
+			z.Cluster,
 			z.Name,
 			z.Namespace,
 			z.Schedule,
@@ -118,24 +242,257 @@ func (f *Formatter) outputCSV(zombies []detector.Zombie) error {
 			fmt.Sprintf("%d", z.FailedJobs),
 			fmt.Sprintf("%d", z.Confidence),
 			fmt.Sprintf("%v", z.IsSuspended),
+			fmt.Sprintf("%d", z.ExpectedRuns),
+			fmt.Sprintf("%d", z.MissedRuns),
+			formatTime(z.NextScheduledRun),
+			z.EvidenceSource,
+			z.Owner,
+			z.OwnerSource,
+			z.CreatedBy,
+			z.LastModifiedBy,
+			formatTime(z.LastModifiedAt),
+			z.ManagedBy,
+			gitOpsSource,
+			suggestedAction,
+			fmt.Sprintf("%d", z.MonthlyRuns),
+			fmt.Sprintf("%g", z.RunDuration.Seconds()),
+			fmt.Sprintf("%g", z.MonthlyCPUHours),
+			fmt.Sprintf("%g", z.MonthlyMemoryGBHours),
+			fmt.Sprintf("%g", z.MonthlyGPUHours),
+			fmt.Sprintf("%.2f", z.EstimatedMonthlyCost),
+			z.CostBasis,
+			fmt.Sprintf("%v", z.Acknowledged),
+			formatSignals(z.Signals),
 		})
 	}
 
 	return nil
 }
 
-func (f *Formatter) outputJSON(zombies []detector.Zombie) error {
+func (f *Formatter) outputJSON(r Result, zombies, acknowledged []detector.Zombie) error {
+	var clusters []clusterJSON
+	for _, c := range r.Clusters {
+		cj := clusterJSON{
+			Name:            c.Name,
+			Status:          c.Status,
+			DurationSeconds: c.Duration.Seconds(),
+			Scanned:         c.Scanned,
+		}
+		for _, z := range c.Zombies {
+			if z.Acknowledged {
+				cj.Acknowledged++
+			} else {
+				cj.Zombies++
+			}
+		}
+		if c.Err != nil {
+			cj.Error = c.Err.Error()
+		}
+		clusters = append(clusters, cj)
+	}
+
+	savings := 0.0
+	for _, z := range zombies {
+		savings += z.EstimatedMonthlyCost
+	}
+
+	owners := map[string]int{}
+	_, groups := owner.Group(zombies)
+	for o, g := range groups {
+		owners[o] = len(g)
+	}
+
 	output := map[string]interface{}{
-		"generated_at":  time.Now().Format(time.RFC3339),
-		"total_zombies": len(zombies),
-		"zombies":       zombies,
+		"generated_at":       time.Now().Format(time.RFC3339),
+		"clusters":           clusters,
+		"scanned":            fleet.Scanned(r.Clusters),
+		"total_zombies":      len(zombies),
+		"total_acknowledged": len(acknowledged),
+		"owners":             owners,
+		"monthly_savings":    math.Round(savings*100) / 100,
+		"currency":           r.Currency,
+		"zombies":            zombies,
+		"acknowledged":       acknowledged,
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(f.out)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
 }
 
+// Explain prints the full signal breakdown for a single CronJob, with its
+// cost estimate in currency
+func (f *Formatter) Explain(z detector.Zombie, thresholdDays int, currency string) error {
+	switch f.format {
+	case "json":
+		encoder := json.NewEncoder(f.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(z)
+	case "csv":
+		w := csv.NewWriter(f.out)
+		defer w.Flush()
+
+		w.Write([]string{"Namespace", "Name", "Signal", "Weight", "Observed", "Message"})
+		for _, s := range z.Signals {
+			w.Write([]string{z.Namespace, z.Name, s.Name, fmt.Sprintf("%d", s.Weight), s.Observed, s.Message})
+		}
+		return nil
+	}
+
+	fmt.Fprintf(f.out, "\n🔬 %s/%s\n", z.Namespace, z.Name)
+	if z.Cluster != "" {
+		fmt.Fprintf(f.out, "Cluster:         %s\n", z.Cluster)
+	}
+	fmt.Fprintf(f.out, "%s\n\n", strings.Repeat("━", 80))
+
+	fmt.Fprintf(f.out, "Schedule:        %s\n", z.Schedule)
+	if z.NextScheduledRun != nil {
+		fmt.Fprintf(f.out, "Next run:        %s\n", z.NextScheduledRun.Format("2006-01-02 15:04:05 MST"))
+	}
+	fmt.Fprintf(f.out, "Threshold:       %d days\n", thresholdDays)
+	fmt.Fprintf(f.out, "Evidence:        %s\n", z.EvidenceSource)
+	fmt.Fprintf(f.out, "Jobs:            %d total, %d failed, %d active\n", z.TotalJobs, z.FailedJobs, z.ActiveJobs)
+	if z.ExpectedRuns > 0 {
+		fmt.Fprintf(f.out, "Missed runs:     %d of %d expected\n", z.MissedRuns, z.ExpectedRuns)
+	}
+	if z.Owner != "" {
+		fmt.Fprintf(f.out, "Owner:           %s (%s)\n", z.Owner, z.OwnerSource)
+	}
+	if z.CreatedBy != "" {
+		fmt.Fprintf(f.out, "Created by:      %s\n", z.CreatedBy)
+		fmt.Fprintf(f.out, "Last modified:   %s by %s\n", formatTime(z.LastModifiedAt), z.LastModifiedBy)
+	}
+	if z.GitOps != nil {
+		fmt.Fprintf(f.out, "Managed by:      %s\n", z.GitOps)
+		fmt.Fprintf(f.out, "Suggested:       %s\n", z.GitOps.Action)
+	} else if z.ManagedBy != "" {
+		fmt.Fprintf(f.out, "Managed by:      %s\n", z.ManagedBy)
+	}
+	if z.CostBasis != "" {
+		fmt.Fprintf(f.out, "Cost:            %s/month (%d runs of %s, %s; %g CPU-hours, %g GB-hours",
+			formatCost(z.EstimatedMonthlyCost, currency), z.MonthlyRuns, z.RunDuration.Round(time.Second), z.CostBasis,
+			z.MonthlyCPUHours, z.MonthlyMemoryGBHours)
+		if z.MonthlyGPUHours > 0 {
+			fmt.Fprintf(f.out, ", %g GPU-hours", z.MonthlyGPUHours)
+		}
+		fmt.Fprintf(f.out, ")\n")
+	}
+
+	fmt.Fprintf(f.out, "\n%-4s %-14s %-8s %-22s %s\n", "", "SIGNAL", "WEIGHT", "OBSERVED", "MESSAGE")
+	fmt.Fprintf(f.out, "%s\n", strings.Repeat("-", 100))
+	for _, s := range z.Signals {
+		weight := fmt.Sprintf("%+d", s.Weight)
+		if s.Weight == 0 {
+			weight = "info"
+		}
+		fmt.Fprintf(f.out, "%-4s %-14s %-8s %-22s %s\n", "", s.Name, weight, s.Observed, s.Message)
+	}
+
+	fmt.Fprintf(f.out, "\n%s\n", strings.Repeat("━", 80))
+	if z.IsZombie {
+		fmt.Fprintf(f.out, "%s ZOMBIE - confidence %d%%\n\n", getEmoji(z.Confidence), z.Confidence)
+	} else if z.Acknowledged {
+		fmt.Fprintf(f.out, "🙈 Acknowledged by %s - would be a zombie at %d%% confidence\n\n", z.AcknowledgedBy, z.Confidence)
+	} else {
+		fmt.Fprintf(f.out, "✅ Healthy\n\n")
+	}
+
+	return nil
+}
+
+// clusterJSON is the per-cluster section of the JSON report
+type clusterJSON struct {
+	Name            string        `json:"name"`
+	Status          string        `json:"status"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Error           string        `json:"error,omitempty"`
+	Zombies         int           `json:"zombies"`
+	Acknowledged    int           `json:"acknowledged"`
+	Scanned         k8s.ScanStats `json:"scanned"`
+}
+
+// splitAcknowledged separates zombies from results their owners acknowledged
+func splitAcknowledged(results []detector.Zombie) (zombies, acknowledged []detector.Zombie) {
+	for _, z := range results {
+		if z.Acknowledged {
+			acknowledged = append(acknowledged, z)
+		} else {
+			zombies = append(zombies, z)
+		}
+	}
+	return zombies, acknowledged
+}
+
+// summarizeAcknowledged counts acknowledged results by what excused them,
+// e.g. "3 (zombie-hunter.io/ignore: 2, rule dr-jobs: 1)"
+func summarizeAcknowledged(acknowledged []detector.Zombie) string {
+	counts := map[string]int{}
+	for _, z := range acknowledged {
+		by := z.AcknowledgedBy
+		if by == "" {
+			by = "unknown"
+		}
+		counts[by]++
+	}
+	var parts []string
+	for _, by := range slices.Sorted(maps.Keys(counts)) {
+		parts = append(parts, fmt.Sprintf("%s: %d", by, counts[by]))
+	}
+	return fmt.Sprintf("%d (%s)", len(acknowledged), strings.Join(parts, ", "))
+}
+
+func formatSignals(signals []detector.Signal) string {
+	var parts []string
+	for _, s := range signals {
+		parts = append(parts, fmt.Sprintf("%s(%+d): %s", s.Name, s.Weight, s.Message))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// formatCost renders an amount such as "12.34 USD"
+func formatCost(amount float64, currency string) string {
+	return strings.TrimSpace(fmt.Sprintf("%.2f %s", amount, currency))
+}
+
+// byCost returns every cluster's results, most expensive first within each
+// cluster, with clusters in scan order
+func byCost(clusters []fleet.Cluster) []detector.Zombie {
+	var results []detector.Zombie
+	for _, c := range clusters {
+		zombies := slices.Clone(c.Zombies)
+		sort.SliceStable(zombies, func(i, j int) bool {
+			return zombies[i].EstimatedMonthlyCost > zombies[j].EstimatedMonthlyCost
+		})
+		results = append(results, zombies...)
+	}
+	return results
+}
+
+// byOwner regroups results ordered by byCost by owner within each cluster,
+// keeping the cost order inside each owner
+func byOwner(zombies []detector.Zombie) []detector.Zombie {
+	var results []detector.Zombie
+	for start := 0; start < len(zombies); {
+		end := start + 1
+		for end < len(zombies) && zombies[end].Cluster == zombies[start].Cluster {
+			end++
+		}
+		owners, groups := owner.Group(zombies[start:end])
+		for _, o := range owners {
+			results = append(results, groups[o]...)
+		}
+		start = end
+	}
+	return results
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 func getEmoji(confidence int) string {
 	if confidence >= 90 {
 		return "💀"
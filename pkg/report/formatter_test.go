@@ -0,0 +1,123 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+)
+
+func TestOutputOwnersAndProvenance(t *testing.T) {
+	result := Result{
+		ThresholdDays: 30,
+		Clusters: []fleet.Cluster{{
+			Name:   "prod",
+			Status: fleet.StatusOK,
+			Zombies: []detector.Zombie{
+				{
+					Namespace: "billing", Name: "invoices", IsZombie: true, Confidence: 95, Owner: "payments", OwnerSource: "label team",
+					Provenance: detector.Provenance{CreatedBy: "helm", LastModifiedBy: "helm", ManagedBy: "Helm", GitOps: &detector.GitOpsSource{
+						Tool: "Helm", Kind: "release", Namespace: "billing", Name: "billing", Action: "remove it from the chart",
+					}},
+				},
+				{Namespace: "billing", Name: "refunds", IsZombie: true, Confidence: 85, Owner: "payments", OwnerSource: "label team"},
+				{Namespace: "tmp", Name: "cleanup", IsZombie: true, Confidence: 60, CostEstimate: detector.CostEstimate{EstimatedMonthlyCost: 10}},
+				{Namespace: "tmp", Name: "dr-drill", Acknowledged: true, AcknowledgedBy: "zombie-hunter.io/ignore-until"},
+				{Namespace: "tmp", Name: "dr-restore", Acknowledged: true, AcknowledgedBy: "rule dr-jobs"},
+			},
+		}},
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+		order  []string
+	}{
+		{format: "table", want: []string{"MANAGED BY", "Helm         0 total", "↳ owner: payments (label team)", "↳ managed by Helm release billing/billing: remove it from the chart",
+			"1 managed by Argo CD, Flux or Helm", "By owner:\n  payments: 2\n  unowned: 1\n",
+			"Acknowledged: 2 (rule dr-jobs: 1, zombie-hunter.io/ignore-until: 1)"}, order: []string{"👤 payments", "invoices", "refunds", "👤 unowned", "cleanup"}},
+		{format: "json", want: []string{`"owners": {`, `"payments": 2`, `"unowned": 1`, `"OwnerSource": "label team"`, `"ManagedBy": "Helm"`, `"Kind": "release"`}},
+		{format: "csv", want: []string{
+			",Owner,OwnerSource,CreatedBy,LastModifiedBy,LastModifiedAt,ManagedBy,GitOpsSource,SuggestedAction,",
+			",payments,label team,helm,helm,,Helm,Helm release billing/billing,remove it from the chart,",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			f := NewFormatter(tt.format)
+			f.out = &out
+			if err := f.Output(result); err != nil {
+				t.Fatalf("Output() failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, out.String())
+				}
+			}
+			last := -1
+			for _, name := range tt.order {
+				i := strings.Index(out.String(), name)
+				if i <= last {
+					t.Errorf("%s is out of order; want %v:\n%s", name, tt.order, out.String())
+				}
+				last = i
+			}
+		})
+	}
+}
+
+func TestOutputByCost(t *testing.T) {
+	result := Result{
+		ThresholdDays: 30,
+		Currency:      "USD",
+		Clusters: []fleet.Cluster{
+			{Name: "prod", Status: fleet.StatusOK, Zombies: []detector.Zombie{
+				{Cluster: "prod", Namespace: "billing", Name: "cheap", IsZombie: true, Confidence: 95, CostEstimate: detector.CostEstimate{EstimatedMonthlyCost: 1.25}},
+				{Cluster: "prod", Namespace: "billing", Name: "pricey", IsZombie: true, Confidence: 60, CostEstimate: detector.CostEstimate{EstimatedMonthlyCost: 40}},
+				{Cluster: "prod", Namespace: "billing", Name: "excused", Acknowledged: true, CostEstimate: detector.CostEstimate{EstimatedMonthlyCost: 99}},
+			}},
+			{Name: "staging", Status: fleet.StatusOK, Zombies: []detector.Zombie{
+				{Cluster: "staging", Namespace: "dev", Name: "gpu", IsZombie: true, Confidence: 70, CostEstimate: detector.CostEstimate{EstimatedMonthlyCost: 500}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		format string
+		order  []string
+		want   []string
+	}{
+		{format: "table", order: []string{"pricey", "cheap", "gpu"}, want: []string{"COST/MONTH", "40.00 USD", "Potential savings: 541.25 USD/month"}},
+		{format: "json", order: []string{`"pricey"`, `"cheap"`, `"gpu"`}, want: []string{`"monthly_savings": 541.25`, `"currency": "USD"`}},
+		{format: "csv", order: []string{",pricey,", ",cheap,", ",gpu,"}, want: []string{",EstimatedMonthlyCost,", ",40.00,"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			f := NewFormatter(tt.format)
+			f.out = &out
+			if err := f.Output(result); err != nil {
+				t.Fatalf("Output() failed: %v", err)
+			}
+			got := out.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output is missing %q:\n%s", want, got)
+				}
+			}
+			last := -1
+			for _, name := range tt.order {
+				i := strings.Index(got, name)
+				if i < last {
+					t.Errorf("%s is out of order; want %v:\n%s", name, tt.order, got)
+				}
+				last = i
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/rrdesai64/zombie-hunter/pkg/metrics"
+)
+
+// outputPrometheus writes the scan as the same metrics `serve` exposes, in
+// the Prometheus text or OpenMetrics format
+func (f *Formatter) outputPrometheus(r Result, format expfmt.Format) error {
+	exporter := metrics.NewExporter()
+	exporter.Update(r.Clusters, time.Now())
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(exporter); err != nil {
+		return err
+	}
+	families, err := registry.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	enc := expfmt.NewEncoder(f.out, format)
+	for _, family := range families {
+		if err := enc.Encode(family); err != nil {
+			return err
+		}
+	}
+	if closer, ok := enc.(expfmt.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// OutputFile writes a scan report to path atomically: readers such as the
+// node_exporter textfile collector see the old file or the new one, never a
+// partial write. The temporary file doesn't end in .prom so it is never
+// collected.
+func (f *Formatter) OutputFile(r Result, path string) error {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	out := f.out
+	f.out = tmp
+	err = f.Output(r)
+	f.out = out
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
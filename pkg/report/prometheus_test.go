@@ -0,0 +1,77 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rrdesai64/zombie-hunter/pkg/detector"
+	"github.com/rrdesai64/zombie-hunter/pkg/fleet"
+)
+
+func TestOutputFilePrometheus(t *testing.T) {
+	result := Result{
+		ThresholdDays: 30,
+		Clusters: []fleet.Cluster{{
+			Name:     "prod",
+			Status:   fleet.StatusOK,
+			Duration: time.Second,
+			Zombies: []detector.Zombie{
+				{Cluster: "prod", Namespace: "billing", Name: "invoices", IsZombie: true, Confidence: 95, DaysSinceSuccess: 120},
+			},
+		}},
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: "prometheus",
+			want: []string{
+				"# TYPE zombie_hunter_cronjob_confidence gauge",
+				`zombie_hunter_cronjob_confidence{cluster="prod",cronjob="invoices",namespace="billing"} 95`,
+				`zombie_hunter_zombies_total{cluster="prod",confidence_bucket="high",namespace="billing"} 1`,
+			},
+		},
+		{
+			format: "openmetrics",
+			want: []string{
+				`zombie_hunter_cronjob_days_since_success{cluster="prod",cronjob="invoices",namespace="billing"} 120.0`,
+				"# TYPE zombie_hunter_scan_failures counter",
+				"# EOF",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "zombies.prom")
+			if err := os.WriteFile(path, []byte("stale"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := NewFormatter(tt.format).OutputFile(result, path); err != nil {
+				t.Fatalf("OutputFile() failed: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(string(data), line+"\n") {
+					t.Errorf("output is missing %q:\n%s", line, data)
+				}
+			}
+
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 {
+				t.Errorf("temporary files left behind: %v", entries)
+			}
+		})
+	}
+}